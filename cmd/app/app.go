@@ -13,5 +13,7 @@ var AppCmd = &cobra.Command{
 func init() {
 	AppCmd.AddCommand(
 		bootstrapCmd,
+		tokensCmd,
+		openapiCmd,
 	)
 }
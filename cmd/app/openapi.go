@@ -0,0 +1,74 @@
+package app
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+	"go-auth-otp-service/src/api/openapi"
+	"go-auth-otp-service/src/api/routes"
+	"log"
+	"os"
+)
+
+var openapiFile string
+
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Generate or check the OpenAPI document of the api.",
+}
+
+var openapiGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Write the OpenAPI document generated from the routes.",
+	Run: func(cmd *cobra.Command, args []string) {
+		document, err := generateOpenAPI()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if err := os.WriteFile(openapiFile, document, 0644); err != nil {
+			log.Fatalln(err)
+		}
+		log.Printf("OpenAPI document has been written to %s successfully!", openapiFile)
+	},
+}
+
+var openapiCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Fail when the OpenAPI document has drifted from the routes.",
+	Run: func(cmd *cobra.Command, args []string) {
+		document, err := generateOpenAPI()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		stored, err := os.ReadFile(openapiFile)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if !bytes.Equal(document, stored) {
+			log.Fatalf("OpenAPI document %s is out of date, run \"app openapi generate\".", openapiFile)
+		}
+		log.Println("OpenAPI document is up to date.")
+	},
+}
+
+// generateOpenAPI registers the routes on a router which is never served and describes them.
+func generateOpenAPI() ([]byte, error) {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	routes.V1Router(router.Group("api/v1"))
+
+	document, err := openapi.Generate(router.Routes())
+	if err != nil {
+		return nil, err
+	}
+	return openapi.Marshal(document)
+}
+
+func init() {
+	openapiCmd.PersistentFlags().StringVar(&openapiFile, "file", "src/api/openapi/openapi.json", "path of the OpenAPI document")
+
+	openapiCmd.AddCommand(
+		openapiGenerateCmd,
+		openapiCheckCmd,
+	)
+}
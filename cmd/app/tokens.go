@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/providers"
+	"log"
+	"time"
+)
+
+var (
+	pruneExpiredDays int
+	pruneRevokedDays int
+	pruneBatchSize   int
+)
+
+var tokensCmd = &cobra.Command{
+	Use:   "tokens",
+	Short: "Maintain the access tokens.",
+}
+
+var tokensPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Hard delete the expired and revoked access tokens past their retention.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := database.Init(); err != nil {
+			log.Fatalf("Database: Service: Failed to Initialize: %v.", err)
+		}
+
+		job := providers.GetJobsContainer().PruneTokensJob
+		job.ExpiredFor = time.Duration(pruneExpiredDays) * 24 * time.Hour
+		job.Retention = time.Duration(pruneRevokedDays) * 24 * time.Hour
+		job.BatchSize = pruneBatchSize
+
+		if err := job.Run(context.Background()); err != nil {
+			log.Fatalln(err)
+		}
+		log.Println("Access tokens have been pruned successfully!")
+	},
+}
+
+func init() {
+	tokensPruneCmd.Flags().IntVar(&pruneExpiredDays, "expired-days", 0, "days since the refresh token expired, ACCESS_TOKEN_PRUNE_EXPIRED_DAYS when 0")
+	tokensPruneCmd.Flags().IntVar(&pruneRevokedDays, "revoked-days", 0, "days since the token was revoked, ACCESS_TOKEN_PRUNE_REVOKED_DAYS when 0")
+	tokensPruneCmd.Flags().IntVar(&pruneBatchSize, "batch-size", 0, "rows deleted per statement, ACCESS_TOKEN_PRUNE_BATCH_SIZE when 0")
+
+	tokensCmd.AddCommand(
+		tokensPruneCmd,
+	)
+}
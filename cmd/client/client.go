@@ -0,0 +1,54 @@
+package client
+
+import (
+	"github.com/spf13/cobra"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/providers"
+	"go-auth-otp-service/src/services/authentication"
+	"log"
+)
+
+var (
+	clientName   string
+	clientScopes []string
+)
+
+// ClientCmd Commands for managing service clients
+var ClientCmd = &cobra.Command{
+	Use:   "clients",
+	Short: "Manage service clients using the client_credentials grant.",
+}
+
+var clientCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new service client and print its credentials.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := database.Init(); err != nil {
+			log.Fatalf("Database: Service: Failed to Initialize: %v.", err)
+		}
+
+		service := &authentication.ClientCredentialsService{
+			ClientRepository: providers.ProvideClientRepository(database.GetInstance()),
+		}
+
+		client, secret, err := service.Create(clientName, clientScopes)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		log.Printf("Client %q has been created successfully!\n", client.Name)
+		log.Printf("client_id:     %s\n", client.ClientID)
+		log.Printf("client_secret: %s\n", secret)
+		log.Println("Store the secret safely, it will not be shown again.")
+	},
+}
+
+func init() {
+	clientCreateCmd.Flags().StringVar(&clientName, "name", "", "name of the service client")
+	clientCreateCmd.Flags().StringSliceVar(&clientScopes, "scopes", nil, "comma separated list of scopes the client may request")
+	_ = clientCreateCmd.MarkFlagRequired("name")
+
+	ClientCmd.AddCommand(
+		clientCreateCmd,
+	)
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"github.com/spf13/cobra"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/database/seeders"
 	"log"
 )
 
@@ -15,7 +17,17 @@ var seedRunCmd = &cobra.Command{
 	Short: "run all seeders",
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Println("Running seeders")
-		//seeders.SeedAuthorization()
+		if err := database.Init(); err != nil {
+			log.Fatalln(err)
+		}
+
+		if err := seeders.SeedAuthorization(); err != nil {
+			log.Fatalln(err)
+		}
+
+		if err := seeders.SeedSuperAdmin(); err != nil {
+			log.Fatalln(err)
+		}
 
 		log.Println("Database has seeded successfully!")
 	},
@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"go-auth-otp-service/cmd/app"
+	"go-auth-otp-service/cmd/client"
 	"go-auth-otp-service/cmd/database"
 	"go-auth-otp-service/src/cache"
 	"go-auth-otp-service/src/config"
@@ -31,6 +32,7 @@ func init() {
 	rootCmd.AddCommand(
 		app.AppCmd,
 		database.DatabaseCmd,
+		client.ClientCmd,
 	)
 }
 
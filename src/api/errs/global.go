@@ -24,6 +24,32 @@ var (
 	PasswordNotMatch        = errors.New("invalid-password-match")
 	InvalidRecoveryCode     = errors.New("invalid-recovery-code")
 	OTPIsNotValid           = errors.New("otp-is-not-valid")
+	ErrPermissionDenied     = errors.New("request-unauthorized")
+	ErrInvalidCredentials   = errors.New("invalid-credentials")
+	ErrInvalid2FACode       = errors.New("invalid-2fa-code")
+	ErrTwoFactorNotActive   = errors.New("two-factor-authenticate-is-not-active")
+	ErrCountryBlocked       = errors.New("login-from-country-is-blocked")
+	ErrRecentAuthRequired   = errors.New("recent-authentication-required")
+	ErrInvalidCSRFToken     = errors.New("invalid-csrf-token")
+	ErrImpersonationDenied  = errors.New("not-allowed-while-impersonating")
+)
+
+// user
+var (
+	ErrUserAlreadyExists = errors.New("user-already-exists")
+	ErrUserIsNotActive   = errors.New("user-is-not-active")
+)
+
+// storage
+var (
+	ErrInvalidImage         = errors.New("invalid-image")
+	ErrImageTooLarge        = errors.New("image-too-large")
+	ErrUnsupportedImageType = errors.New("unsupported-image-type")
+)
+
+// admin
+var (
+	ErrAdminIsNotActive = errors.New("admin-is-not-active")
 )
 
 // token
@@ -33,6 +59,17 @@ var (
 	ErrTokenExpired         = errors.New("token-expired")
 	ErrInvalidToken         = errors.New("invalid-token")
 	ErrInvalidSigningMethod = errors.New("unexpected-signing-method")
+	ErrInvalidSignedLink    = errors.New("invalid-or-expired-link")
+	ErrSessionLimitReached  = errors.New("session-limit-reached")
+	ErrSessionExpired       = errors.New("session-expired")
+	ErrInvalidDPoPProof     = errors.New("invalid-dpop-proof")
+)
+
+// client
+var (
+	ErrInvalidClient        = errors.New("invalid-client")
+	ErrInvalidScope         = errors.New("invalid-scope")
+	ErrUnsupportedGrantType = errors.New("unsupported-grant-type")
 )
 
 // otp
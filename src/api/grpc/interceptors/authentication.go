@@ -0,0 +1,93 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/services"
+	"go-auth-otp-service/src/services/authentication"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"slices"
+	"strings"
+)
+
+// Rule tells the owner type a method must be called by and the scopes or permissions the caller must hold.
+type Rule struct {
+	OwnerType string
+	Scopes    []string
+}
+
+type AuthenticationInterceptor struct {
+	AccessTokenService authentication.IAccessTokenService
+	AuditService       services.IAuditService
+}
+
+// Interceptor authenticates the calls of the methods having a rule with the bearer access token sent in the
+// authorization metadata, like the AuthenticationMiddleware does for http. Methods without a rule are public.
+// It must be attached after RequestContext.
+func (interceptor *AuthenticationInterceptor) Interceptor(rules map[string]Rule) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		rule, ok := rules[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		ctx, err := interceptor.authenticate(ctx, rule, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authenticate validates the access token and returns the context carrying the owner as the actor.
+func (interceptor *AuthenticationInterceptor) authenticate(ctx context.Context, rule Rule, method string) (context.Context, error) {
+	tokenString, ok := strings.CutPrefix(metadataValue(ctx, "authorization"), "Bearer ")
+	if !ok || tokenString == "" {
+		return nil, Status(ctx, codes.Unauthenticated, errs.ErrAuthenticationFailed)
+	}
+
+	token, claims, err := interceptor.AccessTokenService.ValidateWithClaims(tokenString, authentication.AccessToken, rule.OwnerType)
+	if err != nil {
+		if !errors.Is(err, errs.ErrUserIsNotActive) && !errors.Is(err, errs.ErrAdminIsNotActive) && !errors.Is(err, errs.ErrSessionExpired) {
+			err = errs.ErrAuthenticationFailed
+		}
+		return nil, Status(ctx, codes.Unauthenticated, err)
+	}
+
+	// proofs of possession are not checked over gRPC, so tokens bound to a DPoP key are refused
+	if token.DPoPJkt != "" {
+		return nil, Status(ctx, codes.Unauthenticated, errs.ErrInvalidDPoPProof)
+	}
+
+	for _, scope := range rule.Scopes {
+		if !slices.Contains(token.Scopes, scope) && !slices.Contains(claims.Permissions, scope) {
+			return nil, Status(ctx, codes.PermissionDenied, errs.ErrPermissionDenied)
+		}
+	}
+
+	ctx = context.WithValue(ctx, "authenticated-user-id", token.OwnerID)
+	ctx = context.WithValue(ctx, "authenticated-user-type", token.OwnerType)
+	ctx = context.WithValue(ctx, "access-token-uuid", token.Uuid.String())
+	ctx = context.WithValue(ctx, "actor-id", token.OwnerID)
+	ctx = context.WithValue(ctx, "actor-type", token.OwnerType)
+
+	// Every call made while impersonating the owner is recorded, the admin is the one acting.
+	if token.ImpersonatorID != nil {
+		ctx = context.WithValue(ctx, "impersonator-id", *token.ImpersonatorID)
+		ctx = context.WithValue(ctx, "actor-id", *token.ImpersonatorID)
+		ctx = context.WithValue(ctx, "actor-type", "admin")
+		interceptor.AuditService.Record(ctx, services.AuditEntry{
+			Event:     services.AuditImpersonatedRequest,
+			Outcome:   services.AuditSuccess,
+			OwnerID:   token.OwnerID,
+			OwnerType: token.OwnerType,
+			Metadata:  map[string]interface{}{"session": token.Uuid, "method": method},
+		})
+	}
+
+	// Update last used timestamp
+	_, _ = interceptor.AccessTokenService.UpdateLastUsedAt(token)
+	return ctx, nil
+}
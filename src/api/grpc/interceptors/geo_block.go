@@ -0,0 +1,26 @@
+package interceptors
+
+import (
+	"context"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/geoip"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// GeoBlock refuses the calls of the methods from the countries in GEOIP_BLOCKED_COUNTRIES, like the GeoBlock
+// middleware does for http. It must be attached after RequestContext.
+func GeoBlock(methods ...string) grpc.UnaryServerInterceptor {
+	blocked := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		blocked[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ip, _ := ctx.Value("request-ip").(string)
+		if blocked[info.FullMethod] && geoip.GetInstance().IsBlocked(ip) {
+			return nil, Status(ctx, codes.PermissionDenied, errs.ErrCountryBlocked)
+		}
+		return handler(ctx, req)
+	}
+}
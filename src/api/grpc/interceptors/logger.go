@@ -0,0 +1,20 @@
+package interceptors
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"log"
+	"time"
+)
+
+// Logger logs every call in the format of the gin logger, it must be attached after RequestContext.
+func Logger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	ip, _ := ctx.Value("request-ip").(string)
+	log.Printf("[GRPC] %v | %3d | %13v | %15s | %s\n",
+		start.Format("2006/01/02 - 15:04:05"), status.Code(err), time.Since(start), ip, info.FullMethod)
+	return resp, err
+}
@@ -0,0 +1,35 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/ulule/limiter/v3"
+	"go-auth-otp-service/src/api/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// Limit tells the limiter of a method and the key its calls are counted under.
+type Limit struct {
+	Limiter *limiter.Limiter
+	Key     func(ctx context.Context, req interface{}) string
+}
+
+// RateLimiter refuses the calls of the methods having a limit once their key reached it, like the
+// RateLimiterMiddleware does for http. It must be attached after RequestContext.
+func RateLimiter(limits map[string]Limit) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		limit, ok := limits[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		limiterContext, err := limit.Limiter.Get(ctx, limit.Key(ctx, req))
+		if err != nil {
+			return nil, Status(ctx, codes.Internal, errs.SomeThingWentWrong)
+		}
+		if limiterContext.Reached {
+			return nil, Status(ctx, codes.ResourceExhausted, errs.TooManyRequest)
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,50 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/pkg/i18n"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"slices"
+)
+
+// RequestContext carries the request information into the context passed to services, like
+// middlewares.ServiceContext does for http. A valid x-request-id metadata sent by the caller is kept.
+func RequestContext(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	requestUuid, err := uuid.Parse(metadataValue(ctx, "x-request-id"))
+	if err != nil {
+		requestUuid = uuid.New()
+	}
+
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok {
+		if ip, _, err = net.SplitHostPort(p.Addr.String()); err != nil {
+			ip = p.Addr.String()
+		}
+	}
+
+	locale := metadataValue(ctx, "accept-language")
+	if locale != "" && !slices.Contains(i18n.Locales, locale) {
+		locale = "fa"
+	}
+
+	ctx = context.WithValue(ctx, "request-ip", ip)
+	ctx = context.WithValue(ctx, "request-user-agent", metadataValue(ctx, "user-agent"))
+	ctx = context.WithValue(ctx, "request-device-name", metadataValue(ctx, "x-device-name"))
+	ctx = context.WithValue(ctx, "request-device-platform", metadataValue(ctx, "x-device-platform"))
+	ctx = context.WithValue(ctx, "request-app-version", metadataValue(ctx, "x-app-version"))
+	ctx = context.WithValue(ctx, "request-uuid", requestUuid.String())
+	ctx = context.WithValue(ctx, "locale", locale)
+	return handler(ctx, req)
+}
+
+// metadataValue returns the first value of the incoming metadata under the key, or an empty string.
+func metadataValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
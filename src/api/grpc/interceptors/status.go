@@ -0,0 +1,14 @@
+package interceptors
+
+import (
+	"context"
+	"go-auth-otp-service/src/pkg/i18n"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Status returns the status error of the code, its message is the service error localized like the http responses.
+func Status(ctx context.Context, code codes.Code, err error) error {
+	locale, _ := ctx.Value("locale").(string)
+	return status.Error(code, i18n.Localize(locale, err.Error()))
+}
@@ -0,0 +1,226 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ulule/limiter/v3"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/grpc/interceptors"
+	authv1 "go-auth-otp-service/src/api/grpc/proto/auth/v1"
+	authRequests "go-auth-otp-service/src/api/http/requests/authentication"
+	"go-auth-otp-service/src/pkg/permissions"
+	"go-auth-otp-service/src/pkg/validator"
+	"go-auth-otp-service/src/services"
+	"go-auth-otp-service/src/services/authentication"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sort"
+	"strings"
+)
+
+var AuthServiceName = authv1.AuthService_ServiceDesc.ServiceName
+
+// AuthRules lists the methods of the AuthService which need an access token, the others are public.
+// Internal services call ValidateToken and GetUser with the token of their client.
+var AuthRules = map[string]interceptors.Rule{
+	authv1.AuthService_ValidateToken_FullMethodName: {OwnerType: "client"},
+	authv1.AuthService_GetUser_FullMethodName:       {OwnerType: "client", Scopes: []string{permissions.UsersShow}},
+	authv1.AuthService_RevokeToken_FullMethodName:   {OwnerType: "user"},
+}
+
+// GeoBlockedMethods lists the public methods refused from blocked countries, like the otp routes of the http api.
+var GeoBlockedMethods = []string{
+	authv1.AuthService_SendOtp_FullMethodName,
+	authv1.AuthService_VerifyOtp_FullMethodName,
+}
+
+// AuthLimits limits the otp methods with the limiter of the http routes, under the same keys so both apis
+// share the limits.
+func AuthLimits(criticalLimiter *limiter.Limiter) map[string]interceptors.Limit {
+	return map[string]interceptors.Limit{
+		authv1.AuthService_SendOtp_FullMethodName: {
+			Limiter: criticalLimiter,
+			Key: func(ctx context.Context, req interface{}) string {
+				if mobile := req.(*authv1.SendOtpRequest).GetMobile(); mobile != "" {
+					return fmt.Sprintf("otp-%s", mobile)
+				}
+				return fmt.Sprintf("otp-ip-%s", ctx.Value("request-ip"))
+			},
+		},
+		authv1.AuthService_VerifyOtp_FullMethodName: {
+			Limiter: criticalLimiter,
+			Key: func(ctx context.Context, req interface{}) string {
+				return fmt.Sprintf("verify-otp-%s", ctx.Value("request-ip"))
+			},
+		},
+	}
+}
+
+// AuthServer exposes the otp login, the sessions and the users to internal services over gRPC,
+// it mirrors the http controllers on top of the same services.
+type AuthServer struct {
+	authv1.UnimplementedAuthServiceServer
+	RegisterService      authentication.IRegisterService
+	AccessTokenService   authentication.IAccessTokenService
+	UserService          services.IUserService
+	IntrospectionService authentication.IIntrospectionService
+}
+
+func (server *AuthServer) SendOtp(ctx context.Context, req *authv1.SendOtpRequest) (*authv1.SendOtpResponse, error) {
+	request := &authRequests.AuthSendOtpRequest{Mobile: req.Mobile, NationalIdentityCode: req.NationalIdentityCode}
+	if err := validate(ctx, request); err != nil {
+		return nil, err
+	}
+
+	key, err := server.RegisterService.SaveStateAndSendOTP(ctx, request)
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &authv1.SendOtpResponse{Key: key}, nil
+}
+
+func (server *AuthServer) VerifyOtp(ctx context.Context, req *authv1.VerifyOtpRequest) (*authv1.TokenResponse, error) {
+	request := &authRequests.AuthVerifyOTP{Key: req.Key, OTP: req.Otp, RememberDevice: req.RememberDevice, DeviceID: req.DeviceId}
+	if err := validate(ctx, request); err != nil {
+		return nil, err
+	}
+
+	jwt, err := server.RegisterService.VerifyRegisterOTPViaRedisKey(ctx, request)
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return tokenResponse(jwt), nil
+}
+
+func (server *AuthServer) RefreshToken(ctx context.Context, req *authv1.RefreshTokenRequest) (*authv1.TokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, interceptors.Status(ctx, codes.InvalidArgument, errs.RefreshTokenMissing)
+	}
+
+	jwt, err := server.AccessTokenService.RefreshAccessTokens(ctx, req.RefreshToken, "user")
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return tokenResponse(jwt), nil
+}
+
+// ValidateToken checks the access token of a user like the AuthenticationMiddleware and describes it.
+func (server *AuthServer) ValidateToken(ctx context.Context, req *authv1.ValidateTokenRequest) (*authv1.ValidateTokenResponse, error) {
+	introspection := server.IntrospectionService.Introspect(ctx, req.AccessToken)
+	if !introspection.Active {
+		return &authv1.ValidateTokenResponse{Valid: false}, nil
+	}
+
+	resp := &authv1.ValidateTokenResponse{
+		Valid:       true,
+		SessionUuid: introspection.SessionUuid,
+		UserUuid:    introspection.UserUuid,
+		Permissions: introspection.Permissions,
+		ExpiresAt:   introspection.ExpiresAt.Unix(),
+		Acr:         introspection.Acr,
+		DpopJkt:     introspection.DPoPJkt,
+		Actor:       introspection.Actor,
+	}
+	if introspection.AuthTime != nil {
+		resp.AuthTime = introspection.AuthTime.Unix()
+	}
+	return resp, nil
+}
+
+// RevokeToken revokes a session of the calling user, the calling one when no session is given.
+func (server *AuthServer) RevokeToken(ctx context.Context, req *authv1.RevokeTokenRequest) (*authv1.RevokeTokenResponse, error) {
+	sessionUuid, _ := ctx.Value("access-token-uuid").(string)
+	if req.SessionUuid != "" {
+		if impersonatorID, _ := ctx.Value("impersonator-id").(uint); impersonatorID != 0 {
+			return nil, interceptors.Status(ctx, codes.PermissionDenied, errs.ErrImpersonationDenied)
+		}
+		sessionUuid = req.SessionUuid
+	}
+
+	id, err := uuid.Parse(sessionUuid)
+	if err != nil {
+		return nil, interceptors.Status(ctx, codes.InvalidArgument, errs.InvalidUuid)
+	}
+
+	ownerID, _ := ctx.Value("authenticated-user-id").(uint)
+	if err = server.AccessTokenService.RevokeTokenByUuid(ctx, &id, ownerID, "user"); err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &authv1.RevokeTokenResponse{}, nil
+}
+
+func (server *AuthServer) GetUser(ctx context.Context, req *authv1.GetUserRequest) (*authv1.User, error) {
+	id, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		return nil, interceptors.Status(ctx, codes.InvalidArgument, errs.InvalidUuid)
+	}
+
+	user, err := server.UserService.GetByUuid(&id)
+	if err != nil {
+		return nil, interceptors.Status(ctx, codes.NotFound, errs.RecordNotFound)
+	}
+
+	return &authv1.User{
+		Uuid:                 user.Uuid.String(),
+		FirstName:            user.FirstName,
+		LastName:             user.LastName,
+		FatherName:           user.FatherName,
+		Mobile:               user.Mobile,
+		Email:                user.Email,
+		NationalIdentityCode: user.NationalIdentityCode,
+		IsActive:             user.IsActive,
+		ProfileImage:         user.ProfileImage,
+		CreatedAt:            user.CreatedAt.Unix(),
+		UpdatedAt:            user.UpdatedAt.Unix(),
+	}, nil
+}
+
+func tokenResponse(jwt *authentication.JwtDTO) *authv1.TokenResponse {
+	return &authv1.TokenResponse{
+		AccessToken:           jwt.AccessTokenString,
+		RefreshToken:          jwt.RefreshTokenString,
+		TokenType:             jwt.TokenType,
+		AccessTokenExpiresAt:  jwt.AccessTokenExpiresAt.Unix(),
+		RefreshTokenExpiresAt: jwt.RefreshTokenExpiresAt.Unix(),
+		TrustedDeviceToken:    jwt.TrustedDeviceToken,
+	}
+}
+
+// validate validates the request like the http controllers, the errors of the fields make the message.
+func validate(ctx context.Context, request interface{}) error {
+	locale, _ := ctx.Value("locale").(string)
+	fieldErrors := validator.Validate(request, locale)
+	if fieldErrors == nil {
+		return nil
+	}
+
+	messages := make([]string, 0, len(fieldErrors))
+	for field, message := range fieldErrors {
+		messages = append(messages, fmt.Sprintf("%s: %s", field, message))
+	}
+	sort.Strings(messages)
+	return status.Error(codes.InvalidArgument, strings.Join(messages, "; "))
+}
+
+// statusOf maps the errors of the services to status codes, the rest are invalid requests.
+func statusOf(ctx context.Context, err error) error {
+	code := codes.InvalidArgument
+	switch {
+	case errors.Is(err, errs.SomeThingWentWrong):
+		code = codes.Internal
+	case errors.Is(err, errs.RecordNotFound):
+		code = codes.NotFound
+	case errors.Is(err, errs.ErrAuthenticationFailed), errors.Is(err, errs.ErrInvalidRefreshToken),
+		errors.Is(err, errs.ErrTokenExpired), errors.Is(err, errs.ErrInvalidToken), errors.Is(err, errs.ErrSessionExpired):
+		code = codes.Unauthenticated
+	case errors.Is(err, errs.ErrPermissionDenied), errors.Is(err, errs.ErrCountryBlocked):
+		code = codes.PermissionDenied
+	case errors.Is(err, errs.ErrUserIsNotActive), errors.Is(err, errs.ErrSessionLimitReached):
+		code = codes.FailedPrecondition
+	case errors.Is(err, errs.TooManyRequest), errors.Is(err, errs.ErrAuthOTPExists):
+		code = codes.ResourceExhausted
+	}
+	return interceptors.Status(ctx, code, err)
+}
@@ -0,0 +1,139 @@
+package servers
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ulule/limiter/v3"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
+	"go-auth-otp-service/src/api/grpc/interceptors"
+	authv1 "go-auth-otp-service/src/api/grpc/proto/auth/v1"
+	authRequests "go-auth-otp-service/src/api/http/requests/authentication"
+	"go-auth-otp-service/src/pkg/i18n"
+	"go-auth-otp-service/src/services/authentication"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// registerStandIn counts the otps sent instead of sending them.
+type registerStandIn struct {
+	sent int
+}
+
+func (register *registerStandIn) SaveStateAndSendOTP(_ context.Context, req *authRequests.AuthSendOtpRequest) (string, error) {
+	register.sent++
+	return "key-" + req.Mobile, nil
+}
+
+func (register *registerStandIn) VerifyRegisterOTPViaRedisKey(context.Context, *authRequests.AuthVerifyOTP) (*authentication.JwtDTO, error) {
+	return &authentication.JwtDTO{AccessTokenString: "access"}, nil
+}
+
+// dial serves the AuthService, health and reflection like initGrpcServer and returns a grpc-go client connection.
+func dial(t *testing.T, register *registerStandIn, criticalLimiter *limiter.Limiter) (*grpc.ClientConn, *health.Server) {
+	if err := i18n.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptors.RequestContext,
+		interceptors.GeoBlock(GeoBlockedMethods...),
+		interceptors.RateLimiter(AuthLimits(criticalLimiter)),
+	))
+	authv1.RegisterAuthServiceServer(server, &AuthServer{RegisterService: register})
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, healthServer
+}
+
+func TestAuthServerLimitsOtpMethods(t *testing.T) {
+	register := &registerStandIn{}
+	conn, _ := dial(t, register, limiter.New(memory.NewStore(), limiter.Rate{Period: time.Minute, Limit: 2}))
+	client := authv1.NewAuthServiceClient(conn)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.SendOtp(ctx, &authv1.SendOtpRequest{Mobile: "09123456789"})
+		if err != nil || resp.GetKey() != "key-09123456789" {
+			t.Fatalf("SendOtp %d = %v, %v", i, resp, err)
+		}
+	}
+	if _, err := client.SendOtp(ctx, &authv1.SendOtpRequest{Mobile: "09123456789"}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("SendOtp over the limit = %v, want ResourceExhausted", err)
+	}
+	if register.sent != 2 {
+		t.Fatalf("otps sent = %d, want 2", register.sent)
+	}
+
+	// another mobile is counted under its own key
+	if _, err := client.SendOtp(ctx, &authv1.SendOtpRequest{Mobile: "09351234567"}); err != nil {
+		t.Fatalf("SendOtp to another mobile = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.VerifyOtp(ctx, &authv1.VerifyOtpRequest{Key: "key", Otp: "12345"}); status.Code(err) == codes.ResourceExhausted {
+			t.Fatalf("VerifyOtp %d = %v", i, err)
+		}
+	}
+	if _, err := client.VerifyOtp(ctx, &authv1.VerifyOtpRequest{Key: "key", Otp: "12345"}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("VerifyOtp over the limit = %v, want ResourceExhausted", err)
+	}
+}
+
+func TestAuthServerHealthAndReflection(t *testing.T) {
+	conn, healthServer := dial(t, &registerStandIn{}, limiter.New(memory.NewStore(), limiter.Rate{Period: time.Minute, Limit: 2}))
+	ctx := context.Background()
+
+	check, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil || check.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Check = %v, %v, want SERVING", check, err)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = stream.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = stream.CloseSend()
+
+	listed := false
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		listed = listed || service.GetName() == AuthServiceName
+	}
+	if !listed {
+		t.Fatalf("reflection lists %v, want %s", resp.GetListServicesResponse().GetService(), AuthServiceName)
+	}
+
+	healthServer.Shutdown()
+	check, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil || check.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("Check after Shutdown = %v, %v, want NOT_SERVING", check, err)
+	}
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/http/middlewares"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/services"
+	"go-auth-otp-service/src/services/authentication"
+	"net/http"
+	"time"
+)
+
+type AccountController struct {
+	UserService          services.IUserService
+	AccessTokenService   authentication.IAccessTokenService
+	ApiKeyService        authentication.IApiKeyService
+	TrustedDeviceService authentication.ITrustedDeviceService
+	AccountExportService services.IAccountExportService
+}
+
+// Delete soft-deletes the authenticated user, logs them out everywhere and revokes their api keys and trusted devices,
+// their personal data is anonymised once the grace period is over.
+func (controller *AccountController) Delete(c *gin.Context) {
+	user, err := controller.UserService.GetByID(c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// revoked up front so they stay unusable if the deletion is restored
+	if err = controller.ApiKeyService.RevokeAll(user.ID, "user"); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	if err = controller.TrustedDeviceService.RevokeAll(user.ID); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	if err = controller.UserService.RequestDeletion(middlewares.ServiceContext(c), user); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	if err = controller.AccessTokenService.RevokeTokens(middlewares.ServiceContext(c), user.ID, "user"); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
+
+// Export sends every piece of data kept about the authenticated user as a json file,
+// or as a zip archive when format=zip is requested.
+func (controller *AccountController) Export(c *gin.Context) {
+	user, err := controller.UserService.GetByID(c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	export, err := controller.AccountExportService.Export(user)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	filename := fmt.Sprintf("account-export-%s", time.Now().Format("20060102150405"))
+
+	if c.Query("format") == "zip" {
+		archive, err := controller.AccountExportService.Archive(export)
+		if err != nil {
+			response.Api(c).SetMessage(err.Error()).SetLog().Send()
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".zip"))
+		c.Data(http.StatusOK, "application/zip", archive)
+		return
+	}
+
+	body, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".json"))
+	c.Data(http.StatusOK, "application/json", body)
+}
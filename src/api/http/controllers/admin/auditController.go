@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/services"
+	"net/http"
+)
+
+type AuditController struct {
+	AuditService services.IAuditService
+}
+
+// GetList lists the audit events of every owner, filtered by the query parameters.
+func (controller *AuditController) GetList(c *gin.Context) {
+	builder, exists := c.Get("query_parameters_builder")
+	if !exists {
+		response.Api(c).SetStatusCode(http.StatusUnprocessableEntity).SetMessage(errs.SomeThingWentWrong.Error()).SetLog().Send()
+		return
+	}
+
+	events, err := controller.AuditService.GetList(builder.(*scopes.BuilderModel))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"audit_events": events,
+		}).SetLog().Send()
+}
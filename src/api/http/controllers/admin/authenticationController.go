@@ -0,0 +1,131 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/http/middlewares"
+	"go-auth-otp-service/src/api/http/requests/adminRequests"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/pkg/validator"
+	"go-auth-otp-service/src/services/authentication"
+	"net/http"
+)
+
+type AuthenticationController struct {
+	AdminAuthenticationService authentication.IAdminAuthenticationService
+}
+
+func (controller *AuthenticationController) Login(c *gin.Context) {
+	// Bind check payload.
+	var req adminRequests.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	key, method, err := controller.AdminAuthenticationService.Login(middlewares.ServiceContext(c), &req)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetStatusCode(http.StatusUnauthorized).SetLog().Send()
+		return
+	}
+
+	// Return response.
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"key":    key,
+			"method": method,
+		}).SetLog().Send()
+}
+
+func (controller *AuthenticationController) VerifyLogin(c *gin.Context) {
+	// Bind check payload.
+	var req adminRequests.VerifyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	// prepare data for service
+	ctx := middlewares.ServiceContext(c)
+
+	jwt, err := controller.AdminAuthenticationService.VerifyLogin(ctx, &req)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetStatusCode(http.StatusUnauthorized).SetLog().Send()
+		return
+	}
+	middlewares.SetSessionCookies(c, "admin", jwt)
+
+	// Return response.
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"access_tokens": jwt,
+		}).SetLog().Send()
+}
+
+func (controller *AuthenticationController) Me(c *gin.Context) {
+	admin, err := controller.AdminAuthenticationService.GetByID(c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"admin":       admin,
+			"permissions": c.GetStringSlice("authenticated-permissions"),
+		}).SetLog().Send()
+}
+
+func (controller *AuthenticationController) SetupTotp(c *gin.Context) {
+	secret, url, err := controller.AdminAuthenticationService.SetupTotp(c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"secret": secret,
+			"url":    url,
+		}).SetLog().Send()
+}
+
+func (controller *AuthenticationController) EnableTotp(c *gin.Context) {
+	// Bind check payload.
+	var req adminRequests.EnableTotpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	err := controller.AdminAuthenticationService.EnableTotp(c.GetUint("authenticated-user-id"), req.Code)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().Send()
+}
@@ -0,0 +1,322 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/middlewares"
+	"go-auth-otp-service/src/api/http/requests/userRequests"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/utils"
+	"go-auth-otp-service/src/pkg/validator"
+	"go-auth-otp-service/src/services"
+	"go-auth-otp-service/src/services/authentication"
+	"net/http"
+)
+
+type UserController struct {
+	UserService          services.IUserService
+	AccessTokenService   authentication.IAccessTokenService
+	ImpersonationService authentication.IImpersonationService
+}
+
+func (controller *UserController) Create(c *gin.Context) {
+	// Bind check payload.
+	var req userRequests.AdminCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	user, err := controller.UserService.CreateByAdmin(middlewares.ServiceContext(c), &req)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetStatusCode(http.StatusUnprocessableEntity).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusCreated).
+		SetData(map[string]interface{}{
+			"user": user,
+		}).SetLog().Send()
+}
+
+func (controller *UserController) Update(c *gin.Context) {
+	user, ok := controller.findUser(c, false)
+	if !ok {
+		return
+	}
+
+	// Bind check payload.
+	var req userRequests.UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil || utils.IsUpdateRequestEmpty(req) {
+		response.Api(c).SetMessage("invalid-payload").SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	user, err := controller.UserService.UpdateAttributes(middlewares.ServiceContext(c), user, &req)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetStatusCode(http.StatusUnprocessableEntity).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"user": user,
+		}).SetLog().Send()
+}
+
+func (controller *UserController) Deactivate(c *gin.Context) {
+	user, ok := controller.findUser(c, false)
+	if !ok {
+		return
+	}
+
+	user, err := controller.UserService.Deactivate(middlewares.ServiceContext(c), user)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// a deactivated user must not keep any session
+	if err = controller.AccessTokenService.RevokeTokens(middlewares.ServiceContext(c), user.ID, "user"); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"user": user,
+		}).SetLog().Send()
+}
+
+func (controller *UserController) Reactivate(c *gin.Context) {
+	user, ok := controller.findUser(c, false)
+	if !ok {
+		return
+	}
+
+	user, err := controller.UserService.Reactivate(middlewares.ServiceContext(c), user)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"user": user,
+		}).SetLog().Send()
+}
+
+func (controller *UserController) Delete(c *gin.Context) {
+	user, ok := controller.findUser(c, false)
+	if !ok {
+		return
+	}
+
+	if err := controller.UserService.Delete(middlewares.ServiceContext(c), user); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// a deleted user must not keep any session
+	if err := controller.AccessTokenService.RevokeTokens(middlewares.ServiceContext(c), user.ID, "user"); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
+
+func (controller *UserController) Restore(c *gin.Context) {
+	user, ok := controller.findUser(c, true)
+	if !ok {
+		return
+	}
+
+	user, err := controller.UserService.Restore(middlewares.ServiceContext(c), user)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"user": user,
+		}).SetLog().Send()
+}
+
+func (controller *UserController) ForceDelete(c *gin.Context) {
+	user, ok := controller.findUser(c, true)
+	if !ok {
+		return
+	}
+
+	if err := controller.UserService.ForceDelete(middlewares.ServiceContext(c), user); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
+
+func (controller *UserController) GetSessions(c *gin.Context) {
+	user, ok := controller.findUser(c, false)
+	if !ok {
+		return
+	}
+
+	// get the query builder
+	builder, exists := c.Get("query_parameters_builder")
+	if !exists {
+		response.Api(c).SetStatusCode(http.StatusUnprocessableEntity).SetMessage(errs.SomeThingWentWrong.Error()).SetLog().Send()
+		return
+	}
+
+	// fetch information to query builder
+	builderModel := builder.(*scopes.BuilderModel)
+	builderModel.Filters["owner_type"] = "user"
+	builderModel.Filters["owner_id"] = user.ID
+
+	// get list of access token
+	data, err := controller.AccessTokenService.GetActiveTokens(builderModel)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"access_tokens": data,
+		}).SetLog().Send()
+}
+
+func (controller *UserController) RevokeSessions(c *gin.Context) {
+	user, ok := controller.findUser(c, false)
+	if !ok {
+		return
+	}
+
+	if err := controller.AccessTokenService.RevokeTokens(middlewares.ServiceContext(c), user.ID, "user"); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
+
+func (controller *UserController) RevokeSession(c *gin.Context) {
+	user, ok := controller.findUser(c, false)
+	if !ok {
+		return
+	}
+
+	// Get the session UUID from the URL parameter and parse it
+	id, err := uuid.Parse(c.Param("session"))
+	if err != nil {
+		response.Api(c).SetMessage(errs.InvalidUuid.Error()).SetLog().Send()
+		return
+	}
+
+	err = controller.AccessTokenService.RevokeTokenByUuid(middlewares.ServiceContext(c), &id, user.ID, "user")
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
+
+// Impersonate issues short-lived tokens of the user to the authenticated admin, the reason is kept in the audit log.
+func (controller *UserController) Impersonate(c *gin.Context) {
+	user, ok := controller.findUser(c, false)
+	if !ok {
+		return
+	}
+
+	// Bind check payload.
+	var req userRequests.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	jwt, err := controller.ImpersonationService.Impersonate(middlewares.ServiceContext(c), c.GetUint("authenticated-user-id"), user, req.Reason)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetStatusCode(http.StatusUnprocessableEntity).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusCreated).
+		SetData(map[string]interface{}{
+			"access_tokens": jwt,
+		}).SetLog().Send()
+}
+
+// findUser loads the user addressed by the uuid route parameter and writes the error response when it is missing.
+func (controller *UserController) findUser(c *gin.Context, withTrashed bool) (*models.UserModel, bool) {
+	id, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		response.Api(c).SetMessage(errs.InvalidUuid.Error()).SetLog().Send()
+		return nil, false
+	}
+
+	var user *models.UserModel
+	if withTrashed {
+		user, err = controller.UserService.GetByUuidWithTrashed(&id)
+	} else {
+		user, err = controller.UserService.GetByUuid(&id)
+	}
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(errs.RecordNotFound.Error()).SetLog().Send()
+		return nil, false
+	}
+
+	return user, true
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/services"
+	"net/http"
+)
+
+type AuditController struct {
+	AuditService services.IAuditService
+}
+
+// GetMine lists the audit events of the authenticated user.
+func (controller *AuditController) GetMine(c *gin.Context) {
+	builder, exists := c.Get("query_parameters_builder")
+	if !exists {
+		response.Api(c).SetStatusCode(http.StatusUnprocessableEntity).SetMessage(errs.SomeThingWentWrong.Error()).SetLog().Send()
+		return
+	}
+
+	// the owner can not be chosen by the query parameters
+	builderModel := builder.(*scopes.BuilderModel)
+	builderModel.Filters["owner_type"] = "user"
+	builderModel.Filters["owner_id"] = c.GetUint("authenticated-user-id")
+
+	events, err := controller.AuditService.GetList(builderModel)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"audit_events": events,
+		}).SetLog().Send()
+}
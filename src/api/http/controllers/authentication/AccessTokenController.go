@@ -1,14 +1,17 @@
 package authentication
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/middlewares"
 	authentication_request "go-auth-otp-service/src/api/http/requests/authentication"
 	response "go-auth-otp-service/src/api/http/responses"
 	"go-auth-otp-service/src/database/scopes"
 	"go-auth-otp-service/src/pkg/validator"
 	"go-auth-otp-service/src/services/authentication"
+	"io"
 	"net/http"
 )
 
@@ -97,24 +100,42 @@ func (controller *AccessTokenController) GetByUuid(c *gin.Context) {
 }
 
 func (controller *AccessTokenController) RefreshAccessToken(c *gin.Context) {
-	// Get refresh token from cookies
+	controller.refreshAccessToken(c, "user")
+}
+
+func (controller *AccessTokenController) RefreshAdminAccessToken(c *gin.Context) {
+	controller.refreshAccessToken(c, "admin")
+}
+
+func (controller *AccessTokenController) refreshAccessToken(c *gin.Context, ownerType string) {
+	// browsers in the session cookie mode may send an empty body
 	var req authentication_request.RefreshAccessTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
 		response.Api(c).SetLog().Send()
 		return
 	}
 
+	// Get refresh token from cookies
+	if req.RefreshToken == "" {
+		var err error
+		if req.RefreshToken, err = middlewares.RefreshTokenFromCookie(c, ownerType); err != nil {
+			response.Api(c).SetMessage(err.Error()).SetStatusCode(http.StatusForbidden).SetLog().Send()
+			return
+		}
+	}
+
 	// validate request
 	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
 		response.Api(c).SetErrors(err).Send()
 		return
 	}
 
-	jwt, err := controller.AccessTokenService.RefreshAccessTokens(req.RefreshToken, "user")
+	jwt, err := controller.AccessTokenService.RefreshAccessTokens(middlewares.ServiceContext(c), req.RefreshToken, ownerType)
 	if err != nil {
 		response.Api(c).SetMessage(err.Error()).SetStatusCode(http.StatusUnauthorized).SetLog().Send()
 		return
 	}
+	middlewares.SetSessionCookies(c, ownerType, jwt)
 
 	// Return response
 	response.Api(c).SetMessage("request-successful").
@@ -126,7 +147,7 @@ func (controller *AccessTokenController) RefreshAccessToken(c *gin.Context) {
 
 func (controller *AccessTokenController) RevokeTokens(c *gin.Context) {
 	// revoke tokens
-	err := controller.AccessTokenService.RevokeTokens(c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"))
+	err := controller.AccessTokenService.RevokeTokens(middlewares.ServiceContext(c), c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"))
 	if err != nil {
 		response.Api(c).SetMessage(err.Error()).SetLog().Send()
 		return
@@ -149,7 +170,7 @@ func (controller *AccessTokenController) RevokeTokenByUUID(c *gin.Context) {
 	}
 
 	// revoke the token by it's uuid
-	err = controller.AccessTokenService.RevokeTokenByUuid(&id, c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"))
+	err = controller.AccessTokenService.RevokeTokenByUuid(middlewares.ServiceContext(c), &id, c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"))
 	if err != nil {
 		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
 		return
@@ -172,11 +193,12 @@ func (controller *AccessTokenController) RevokeCurrentToken(c *gin.Context) {
 	}
 
 	// revoke the token by it's uuid
-	err = controller.AccessTokenService.RevokeTokenByUuid(&id, c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"))
+	err = controller.AccessTokenService.RevokeTokenByUuid(middlewares.ServiceContext(c), &id, c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"))
 	if err != nil {
 		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
 		return
 	}
+	middlewares.ClearSessionCookies(c, c.GetString("authenticated-user-type"))
 
 	// send response
 	response.Api(c).SetMessage("request-successful").
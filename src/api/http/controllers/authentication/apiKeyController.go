@@ -0,0 +1,104 @@
+package authentication
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/api/errs"
+	authRequests "go-auth-otp-service/src/api/http/requests/authentication"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/pkg/validator"
+	"go-auth-otp-service/src/services/authentication"
+	"net/http"
+)
+
+type ApiKeyController struct {
+	ApiKeyService authentication.IApiKeyService
+}
+
+func (controller *ApiKeyController) GetList(c *gin.Context) {
+	// get the query builder
+	builder, exists := c.Get("query_parameters_builder")
+	if !exists {
+		response.Api(c).SetStatusCode(http.StatusUnprocessableEntity).SetMessage(errs.SomeThingWentWrong.Error()).SetLog().Send()
+		return
+	}
+
+	// fetch information to query builder
+	builderModel := builder.(*scopes.BuilderModel)
+	builderModel.Filters["owner_type"] = c.GetString("authenticated-user-type")
+	builderModel.Filters["owner_id"] = c.GetUint("authenticated-user-id")
+
+	// get list of api keys
+	data, err := controller.ApiKeyService.GetList(builderModel)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// return response
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"api_keys": data,
+		}).SetLog().Send()
+}
+
+func (controller *ApiKeyController) Create(c *gin.Context) {
+	// api keys can not be used to mint new api keys
+	if c.GetString("authentication-method") == "api-key" {
+		response.Api(c).SetStatusCode(http.StatusForbidden).SetMessage(errs.ErrPermissionDenied.Error()).SetLog().Send()
+		return
+	}
+
+	// Bind check payload.
+	var req authRequests.CreateApiKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	// create the api key
+	apiKey, key, err := controller.ApiKeyService.Create(c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"), &req)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// send response, the key is only shown once
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusCreated).
+		SetData(map[string]interface{}{
+			"api_key": apiKey,
+			"key":     key,
+		}).SetLog().Send()
+}
+
+func (controller *ApiKeyController) Revoke(c *gin.Context) {
+	// Get the UUID from the URL parameter and parse it
+	uuidStr := c.Param("uuid")
+	id, err := uuid.Parse(uuidStr)
+	if err != nil {
+		response.Api(c).SetMessage(errs.InvalidUuid.Error()).SetLog().Send()
+		return
+	}
+
+	// revoke the api key by it's uuid
+	err = controller.ApiKeyService.Revoke(&id, c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// send response
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
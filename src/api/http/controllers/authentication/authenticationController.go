@@ -3,11 +3,11 @@ package authentication
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"go-auth-otp-service/src/api/http/middlewares"
 	authRequests "go-auth-otp-service/src/api/http/requests/authentication"
 	response "go-auth-otp-service/src/api/http/responses"
 	"go-auth-otp-service/src/pkg/validator"
 	"go-auth-otp-service/src/services/authentication"
-	"golang.org/x/net/context"
 
 	"net/http"
 )
@@ -30,7 +30,7 @@ func (controller *RegisterController) SendOtp(c *gin.Context) {
 		return
 	}
 
-	key, err := controller.RegisterService.SaveStateAndSendOTP(&req)
+	key, err := controller.RegisterService.SaveStateAndSendOTP(middlewares.ServiceContext(c), &req)
 	if err != nil {
 		response.Api(c).SetMessage(err.Error()).SetLog().Send()
 		return
@@ -58,8 +58,7 @@ func (controller *RegisterController) VerifyOtp(c *gin.Context) {
 		return
 	}
 	// prepare data for service
-	ctx := context.WithValue(context.Background(), "request-ip", c.GetString("request-ip"))
-	ctx = context.WithValue(ctx, "request-user-agent", c.GetHeader("User-Agent"))
+	ctx := middlewares.ServiceContext(c)
 	// register user
 	jwt, err := controller.RegisterService.VerifyRegisterOTPViaRedisKey(ctx, &req)
 	if err != nil {
@@ -67,6 +66,10 @@ func (controller *RegisterController) VerifyOtp(c *gin.Context) {
 		resp.SetLog().Send()
 		return
 	}
+	// browsers keep the token of a remembered device in a cookie
+	SetTrustedDeviceCookie(c, jwt.TrustedDeviceToken)
+	middlewares.SetSessionCookies(c, "user", jwt)
+
 	// Return response.
 	response.Api(c).SetMessage("request-successful").
 		SetStatusCode(http.StatusOK).
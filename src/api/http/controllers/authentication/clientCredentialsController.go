@@ -0,0 +1,67 @@
+package authentication
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/middlewares"
+	authRequests "go-auth-otp-service/src/api/http/requests/authentication"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/pkg/validator"
+	"go-auth-otp-service/src/services/authentication"
+	"net/http"
+)
+
+type ClientCredentialsController struct {
+	ClientCredentialsService authentication.IClientCredentialsService
+}
+
+func (controller *ClientCredentialsController) IssueToken(c *gin.Context) {
+	// Bind check payload.
+	var req authRequests.ClientCredentialsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// client credentials may also be sent through basic authentication
+	if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+		req.ClientID, req.ClientSecret = clientID, clientSecret
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	// prepare data for service
+	ctx := middlewares.ServiceContext(c)
+
+	// issue token
+	jwt, err := controller.ClientCredentialsService.IssueToken(ctx, &req)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetStatusCode(tokenErrorStatus(err)).SetLog().Send()
+		return
+	}
+
+	// Return response.
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"access_token":            jwt.AccessTokenString,
+			"access_token_expires_at": jwt.AccessTokenExpiresAt,
+			"token_type":              "Bearer",
+			"scopes":                  jwt.Scopes,
+		}).
+		SetLog().
+		Send()
+}
+
+// tokenErrorStatus answers the errors of the request with 400 and a failed client authentication with 401.
+func tokenErrorStatus(err error) int {
+	if errors.Is(err, errs.ErrUnsupportedGrantType) || errors.Is(err, errs.ErrInvalidScope) {
+		return http.StatusBadRequest
+	}
+	return http.StatusUnauthorized
+}
@@ -0,0 +1,73 @@
+package authentication
+
+import (
+	"bytes"
+	_ "embed"
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/http/middlewares"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/pkg/i18n"
+	"go-auth-otp-service/src/services/authentication"
+	"html/template"
+	"net/http"
+)
+
+// notMePage asks to confirm the revocation before anything changes, link scanners and prefetchers only open it.
+//
+//go:embed notMe.html
+var notMePage string
+
+var notMeTemplate = template.Must(template.New("not-me").Parse(notMePage))
+
+type DeviceController struct {
+	DeviceService authentication.IDeviceService
+}
+
+// ConfirmNotMe shows the page of the signed link of a new device notification, whose form posts back to NotMe.
+// Opening the link changes nothing.
+func (controller *DeviceController) ConfirmNotMe(c *gin.Context) {
+	err := controller.DeviceService.CheckRevokeLink(c.Param("id"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusForbidden).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	locale := c.GetString("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	var page bytes.Buffer
+	err = notMeTemplate.Execute(&page, map[string]string{
+		"Lang":    locale,
+		"Title":   i18n.Localize(locale, "not-me-title"),
+		"Body":    i18n.Localize(locale, "not-me-body"),
+		"Confirm": i18n.Localize(locale, "not-me-confirm"),
+		"Action":  c.Request.URL.RequestURI(),
+	})
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// the signature is in the url, it must not leak through caches or the referer
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// NotMe revokes the session of a new device notification and the device it trusted,
+// it is reached from the signed link without a token once the user confirmed it.
+func (controller *DeviceController) NotMe(c *gin.Context) {
+	err := controller.DeviceService.RevokeFromLink(middlewares.ServiceContext(c), c.Param("id"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusForbidden).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("session-revoked").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
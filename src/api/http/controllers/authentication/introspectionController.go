@@ -0,0 +1,41 @@
+package authentication
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/http/middlewares"
+	authRequests "go-auth-otp-service/src/api/http/requests/authentication"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/pkg/validator"
+	"go-auth-otp-service/src/services/authentication"
+	"net/http"
+)
+
+type IntrospectionController struct {
+	IntrospectionService authentication.IIntrospectionService
+}
+
+// Introspect tells the calling client whether the access token of a user is active and who it belongs to.
+// Inactive tokens are not an error, the client reads active.
+func (controller *IntrospectionController) Introspect(c *gin.Context) {
+	// Bind check payload.
+	var req authRequests.IntrospectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	introspection := controller.IntrospectionService.Introspect(middlewares.ServiceContext(c), req.Token)
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"introspection": introspection,
+		}).
+		Send()
+}
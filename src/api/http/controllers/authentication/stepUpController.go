@@ -0,0 +1,74 @@
+package authentication
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/middlewares"
+	authRequests "go-auth-otp-service/src/api/http/requests/authentication"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/pkg/validator"
+	"go-auth-otp-service/src/services/authentication"
+	"net/http"
+)
+
+type StepUpController struct {
+	StepUpService authentication.IStepUpService
+}
+
+// Challenge starts a step-up of the current session and tells which code to verify.
+func (controller *StepUpController) Challenge(c *gin.Context) {
+	method, err := controller.StepUpService.Challenge(middlewares.ServiceContext(c),
+		c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"))
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"method": method,
+		}).
+		SetLog().
+		Send()
+}
+
+// Verify checks the code and marks the current session as recently authenticated.
+func (controller *StepUpController) Verify(c *gin.Context) {
+	// Bind check payload.
+	var req authRequests.StepUpVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	// only sessions can be stepped up, api keys have none
+	accessTokenUuid, err := uuid.Parse(c.GetString("access-token-uuid"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusForbidden).SetMessage(errs.ErrPermissionDenied.Error()).SetLog().Send()
+		return
+	}
+
+	accessToken, err := controller.StepUpService.Verify(middlewares.ServiceContext(c), &accessTokenUuid,
+		c.GetUint("authenticated-user-id"), c.GetString("authenticated-user-type"), req.Code)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusUnauthorized).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"auth_time": accessToken.AuthTime,
+			"acr":       accessToken.Acr,
+		}).
+		SetLog().
+		Send()
+}
@@ -0,0 +1,127 @@
+package authentication
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/middlewares"
+	authRequests "go-auth-otp-service/src/api/http/requests/authentication"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/pkg/validator"
+	"go-auth-otp-service/src/services/authentication"
+	"net/http"
+	"strings"
+)
+
+// trustedDeviceCookie holds the trusted device token of browsers, apps send it in the body instead.
+const trustedDeviceCookie = "trusted_device_token"
+
+type TrustedDeviceController struct {
+	TrustedDeviceService authentication.ITrustedDeviceService
+}
+
+// Login issues new tokens to a trusted device without an otp.
+func (controller *TrustedDeviceController) Login(c *gin.Context) {
+	// Bind check payload.
+	var req authRequests.TrustedDeviceLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+	if req.Token == "" {
+		req.Token, _ = c.Cookie(trustedDeviceCookie)
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	jwt, err := controller.TrustedDeviceService.Login(middlewares.ServiceContext(c), &req)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusUnauthorized).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+	SetTrustedDeviceCookie(c, jwt.TrustedDeviceToken)
+	middlewares.SetSessionCookies(c, "user", jwt)
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"access_tokens": jwt,
+		}).
+		SetLog().
+		Send()
+}
+
+func (controller *TrustedDeviceController) GetList(c *gin.Context) {
+	// get the query builder
+	builder, exists := c.Get("query_parameters_builder")
+	if !exists {
+		response.Api(c).SetStatusCode(http.StatusUnprocessableEntity).SetMessage(errs.SomeThingWentWrong.Error()).SetLog().Send()
+		return
+	}
+
+	// fetch information to query builder
+	builderModel := builder.(*scopes.BuilderModel)
+	builderModel.Filters["user_id"] = c.GetUint("authenticated-user-id")
+
+	data, err := controller.TrustedDeviceService.GetList(builderModel)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"trusted_devices": data,
+		}).SetLog().Send()
+}
+
+func (controller *TrustedDeviceController) Revoke(c *gin.Context) {
+	// Get the UUID from the URL parameter and parse it
+	id, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		response.Api(c).SetMessage(errs.InvalidUuid.Error()).SetLog().Send()
+		return
+	}
+
+	err = controller.TrustedDeviceService.Revoke(&id, c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
+
+func (controller *TrustedDeviceController) RevokeAll(c *gin.Context) {
+	if err := controller.TrustedDeviceService.RevokeAll(c.GetUint("authenticated-user-id")); err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
+
+// SetTrustedDeviceCookie stores the trusted device token in an http only cookie scoped to the authentication routes.
+func SetTrustedDeviceCookie(c *gin.Context, token string) {
+	if token == "" {
+		return
+	}
+
+	secure := strings.HasPrefix(config.GetInstance().Get("APP_URL"), "https://")
+	c.SetSameSite(http.SameSiteStrictMode)
+	c.SetCookie(trustedDeviceCookie, token, int(authentication.TrustedDeviceLifetime().Seconds()),
+		"/api/v1/authentication", "", secure, true)
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/requests/userRequests"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/pkg/validator"
+	"go-auth-otp-service/src/services"
+	"net/http"
+)
+
+type AuthorizationController struct {
+	AuthorizationService services.IAuthorizationService
+	UserService          services.IUserService
+}
+
+func (controller *AuthorizationController) GetRoles(c *gin.Context) {
+	builder, exists := c.Get("query_parameters_builder")
+	if !exists {
+		response.Api(c).SetStatusCode(http.StatusUnprocessableEntity).SetMessage(errs.SomeThingWentWrong.Error()).SetLog().Send()
+		return
+	}
+
+	// get role list
+	roles, err := controller.AuthorizationService.GetRoles(builder.(*scopes.BuilderModel))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// send response
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"roles": roles,
+		}).SetLog().Send()
+}
+
+func (controller *AuthorizationController) GetPermissions(c *gin.Context) {
+	builder, exists := c.Get("query_parameters_builder")
+	if !exists {
+		response.Api(c).SetStatusCode(http.StatusUnprocessableEntity).SetMessage(errs.SomeThingWentWrong.Error()).SetLog().Send()
+		return
+	}
+
+	// get permission list
+	permissions, err := controller.AuthorizationService.GetPermissions(builder.(*scopes.BuilderModel))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// send response
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"permissions": permissions,
+		}).SetLog().Send()
+}
+
+func (controller *AuthorizationController) GetUserRoles(c *gin.Context) {
+	// Get the UUID from the URL parameter
+	id, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		response.Api(c).SetMessage(errs.InvalidUuid.Error()).SetLog().Send()
+		return
+	}
+
+	user, err := controller.UserService.GetByUuid(&id)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(errs.RecordNotFound.Error()).SetLog().Send()
+		return
+	}
+
+	// get the roles of the user
+	roles, err := controller.AuthorizationService.GetOwnerRoles(user.ID, "user")
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// send response
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"roles": roles,
+		}).SetLog().Send()
+}
+
+func (controller *AuthorizationController) AssignUserRole(c *gin.Context) {
+	// Get the UUID from the URL parameter
+	id, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		response.Api(c).SetMessage(errs.InvalidUuid.Error()).SetLog().Send()
+		return
+	}
+
+	// Bind check payload.
+	var req userRequests.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Api(c).SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	user, err := controller.UserService.GetByUuid(&id)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(errs.RecordNotFound.Error()).SetLog().Send()
+		return
+	}
+
+	// assign the role
+	err = controller.AuthorizationService.AssignRole(user.ID, "user", req.Role)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// send response
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().Send()
+}
+
+func (controller *AuthorizationController) RevokeUserRole(c *gin.Context) {
+	// Get the UUID from the URL parameter
+	id, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		response.Api(c).SetMessage(errs.InvalidUuid.Error()).SetLog().Send()
+		return
+	}
+
+	user, err := controller.UserService.GetByUuid(&id)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(errs.RecordNotFound.Error()).SetLog().Send()
+		return
+	}
+
+	// revoke the role
+	err = controller.AuthorizationService.RevokeRole(user.ID, "user", c.Param("role"))
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	// send response
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().Send()
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/services"
+	"net/http"
+)
+
+type HealthController struct {
+	HealthService services.IHealthService
+}
+
+// Live reports the process is up, it checks no dependency so a failing one does not restart the instance.
+func (controller *HealthController) Live(c *gin.Context) {
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]any{
+			"status": services.HealthUp,
+		}).
+		Send()
+}
+
+// Ready reports whether the dependencies are usable, with 503 while one is down or the application shuts down.
+func (controller *HealthController) Ready(c *gin.Context) {
+	readiness := controller.HealthService.Ready(c.Request.Context())
+
+	builder := response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]any{
+			"readiness": readiness,
+		})
+	if !readiness.Ready {
+		builder.SetMessage("service-not-ready").SetStatusCode(http.StatusServiceUnavailable).SetLog()
+	}
+	builder.Send()
+}
@@ -0,0 +1,19 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/openapi"
+	"net/http"
+)
+
+type OpenAPIController struct{}
+
+// Spec sends the OpenAPI document of the api.
+func (controller *OpenAPIController) Spec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", openapi.Spec)
+}
+
+// SwaggerUI sends the page browsing the OpenAPI document.
+func (controller *OpenAPIController) SwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.SwaggerUI)
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/services"
+	"net/http"
+)
+
+// profileImageFormOverhead is the room left for the boundaries and headers of the multipart form.
+const profileImageFormOverhead = 64 << 10
+
+type ProfileImageController struct {
+	UserService         services.IUserService
+	ProfileImageService services.IProfileImageService
+}
+
+func (controller *ProfileImageController) Upload(c *gin.Context) {
+	// cap the body before the multipart form is parsed and spilled to disk, leaving room for the multipart headers
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.ProfileImageMaxSize()+profileImageFormOverhead)
+
+	// get the uploaded file
+	file, err := c.FormFile("image")
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			response.Api(c).SetMessage(errs.ErrImageTooLarge.Error()).SetStatusCode(http.StatusRequestEntityTooLarge).SetLog().Send()
+			return
+		}
+		response.Api(c).SetMessage(errs.ErrInvalidImage.Error()).SetLog().Send()
+		return
+	}
+
+	user, err := controller.UserService.GetByID(c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	user, err = controller.ProfileImageService.Upload(user, file)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetStatusCode(http.StatusUnprocessableEntity).SetLog().Send()
+		return
+	}
+
+	urls, err := controller.ProfileImageService.URLs(user)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"user":           user,
+			"profile_images": urls,
+		}).SetLog().Send()
+}
+
+func (controller *ProfileImageController) Show(c *gin.Context) {
+	user, err := controller.UserService.GetByID(c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	urls, err := controller.ProfileImageService.URLs(user)
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"profile_images": urls,
+		}).SetLog().Send()
+}
+
+func (controller *ProfileImageController) Delete(c *gin.Context) {
+	user, err := controller.UserService.GetByID(c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	if _, err = controller.ProfileImageService.Delete(user); err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetLog().
+		Send()
+}
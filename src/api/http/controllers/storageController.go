@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/storage"
+	"net/http"
+	"strings"
+)
+
+type StorageController struct{}
+
+// Serve sends a file of the local storage driver after verifying its signed url.
+func (controller *StorageController) Serve(c *gin.Context) {
+	path := strings.TrimPrefix(c.Param("path"), "/")
+
+	file, err := storage.GetInstance().Open(path, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(errs.RecordNotFound.Error()).SetLog().Send()
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(errs.RecordNotFound.Error()).SetLog().Send()
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), file)
+}
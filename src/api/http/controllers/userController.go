@@ -4,8 +4,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/middlewares"
+	"go-auth-otp-service/src/api/http/requests/userRequests"
 	response "go-auth-otp-service/src/api/http/responses"
 	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/pkg/utils"
+	"go-auth-otp-service/src/pkg/validator"
 	"go-auth-otp-service/src/services"
 	"net/http"
 )
@@ -63,3 +67,52 @@ func (controller *UserController) GetByUuid(c *gin.Context) {
 			"user": user,
 		}).SetLog().Send()
 }
+
+func (controller *UserController) Me(c *gin.Context) {
+	user, err := controller.UserService.GetByID(c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"user": user,
+		}).SetLog().Send()
+}
+
+func (controller *UserController) UpdateMe(c *gin.Context) {
+	// Bind check payload.
+	var req userRequests.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil || utils.IsUpdateRequestEmpty(req) {
+		response.Api(c).SetMessage("invalid-payload").SetLog().Send()
+		return
+	}
+
+	// validate the payload.
+	if err := validator.Validate(&req, c.GetString("locale")); err != nil {
+		response.Api(c).SetErrors(err).SetLog().Send()
+		return
+	}
+
+	user, err := controller.UserService.GetByID(c.GetUint("authenticated-user-id"))
+	if err != nil {
+		response.Api(c).SetStatusCode(http.StatusNotFound).SetMessage(err.Error()).SetLog().Send()
+		return
+	}
+
+	user, err = controller.UserService.UpdateProfile(middlewares.ServiceContext(c), user, &req)
+	if err != nil {
+		response.Api(c).SetMessage(err.Error()).SetStatusCode(http.StatusUnprocessableEntity).SetLog().Send()
+		return
+	}
+
+	response.Api(c).
+		SetMessage("request-successful").
+		SetStatusCode(http.StatusOK).
+		SetData(map[string]interface{}{
+			"user": user,
+		}).SetLog().Send()
+}
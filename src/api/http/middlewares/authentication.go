@@ -1,16 +1,23 @@
 package middlewares
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"go-auth-otp-service/src/api/errs"
 	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/services"
 	"go-auth-otp-service/src/services/authentication"
 	"net/http"
+	"slices"
 	"strings"
 )
 
 type AuthenticationMiddleware struct {
-	AccessTokenService authentication.IAccessTokenService
+	AccessTokenService   authentication.IAccessTokenService
+	ApiKeyService        authentication.IApiKeyService
+	AuthorizationService services.IAuthorizationService
+	DPoPService          authentication.IDPoPService
+	AuditService         services.IAuditService
 }
 
 // Middleware wraps the AuthenticationMiddleware method to make it compatible with Gin.
@@ -30,12 +37,29 @@ func (service *AuthenticationMiddleware) Middleware(ownerType string) gin.Handle
 // It checks the Authorization header for a valid JWT, validates it, and sets user context
 // information if the authentication is successful.
 func (service *AuthenticationMiddleware) isAuthenticated(context *gin.Context, ownerType string) bool {
-	// Define the expected prefix for the Authorization header.
+	// Define the expected prefixes for the Authorization header.
 	const BearerSchema = "Bearer "
+	const DPoPSchema = authentication.DPoPScheme + " "
 
 	// Retrieve the Authorization header from the request.
 	header := context.GetHeader("Authorization")
 
+	// Browsers in the session cookie mode send the access token in a cookie, guarded by the csrf token.
+	if header == "" && SessionCookieMode() {
+		if cookie, err := context.Cookie(accessTokenCookie(ownerType)); err == nil && cookie != "" {
+			if !VerifyCSRF(context) {
+				response.Api(context).SetMessage(errs.ErrInvalidCSRFToken.Error()).SetStatusCode(http.StatusForbidden).SetLog().Send()
+				return false
+			}
+			return service.isAccessTokenAuthenticated(context, cookie, ownerType, false)
+		}
+	}
+
+	// Access tokens bound to a DPoP key use their own scheme and come with a proof of the key.
+	if strings.HasPrefix(header, DPoPSchema) && len(header) > len(DPoPSchema) {
+		return service.isAccessTokenAuthenticated(context, header[len(DPoPSchema):], ownerType, true)
+	}
+
 	// Series of if conditions to validate the presence and format of the 'Authorization' header.
 	// Each condition sets isAuthenticated to false if a specific check fails.
 
@@ -48,8 +72,35 @@ func (service *AuthenticationMiddleware) isAuthenticated(context *gin.Context, o
 	// Extract the JWT token from the Authorization header, removing the 'Bearer ' prefix.
 	tokenString := header[len(BearerSchema):]
 
+	// Personal access tokens are recognised by their prefix and validated separately.
+	if strings.HasPrefix(tokenString, authentication.ApiKeyPrefix) {
+		return service.isApiKeyAuthenticated(context, tokenString, ownerType)
+	}
+
+	return service.isAccessTokenAuthenticated(context, tokenString, ownerType, false)
+}
+
+// isAccessTokenAuthenticated validates an access token and sets the owner context information.
+func (service *AuthenticationMiddleware) isAccessTokenAuthenticated(context *gin.Context, tokenString string, ownerType string, usesDPoP bool) bool {
 	// Validate the JWT both JWT and database.
-	token, err := service.AccessTokenService.Validate(tokenString, authentication.AccessToken, ownerType)
+	token, _, err := service.AccessTokenService.ValidateWithClaims(tokenString, authentication.AccessToken, ownerType)
+	if err != nil {
+		response.Api(context).SetMessage(authenticationFailureMessage(err)).SetStatusCode(http.StatusUnauthorized).SetLog().Send()
+		return false
+	}
+
+	// A token bound to a DPoP key is only honoured with the DPoP scheme and a fresh proof of the key.
+	if token.DPoPJkt != "" || usesDPoP {
+		if !usesDPoP || !service.hasProofOfPossession(context, tokenString, token.DPoPJkt) {
+			context.Header("WWW-Authenticate", authentication.DPoPScheme+` error="invalid_dpop_proof"`)
+			response.Api(context).SetMessage(errs.ErrInvalidDPoPProof.Error()).SetStatusCode(http.StatusUnauthorized).SetLog().Send()
+			return false
+		}
+	}
+
+	// The permissions are read again rather than taken from the claims, so a revoked role stops working at once
+	// instead of once the token is refreshed.
+	permissions, err := service.AuthorizationService.GetOwnerPermissions(token.OwnerID, token.OwnerType)
 	if err != nil {
 		response.Api(context).SetMessage(errs.ErrAuthenticationFailed.Error()).SetStatusCode(http.StatusUnauthorized).SetLog().Send()
 		return false
@@ -59,6 +110,28 @@ func (service *AuthenticationMiddleware) isAuthenticated(context *gin.Context, o
 	context.Set("authenticated-user-id", token.OwnerID)
 	context.Set("authenticated-user-type", token.OwnerType)
 	context.Set("access-token-uuid", token.Uuid.String())
+	context.Set("authenticated-scopes", []string(token.Scopes))
+	context.Set("authenticated-permissions", permissions)
+	context.Set("authentication-method", "access-token")
+	if token.AuthTime != nil {
+		context.Set("auth-time", *token.AuthTime)
+	}
+
+	// Every request made while impersonating the owner is recorded for the owner and the admin to see.
+	if token.ImpersonatorID != nil {
+		context.Set("impersonator-id", *token.ImpersonatorID)
+		service.AuditService.Record(ServiceContext(context), services.AuditEntry{
+			Event:     services.AuditImpersonatedRequest,
+			Outcome:   services.AuditSuccess,
+			OwnerID:   token.OwnerID,
+			OwnerType: token.OwnerType,
+			Metadata: map[string]interface{}{
+				"session": token.Uuid,
+				"method":  context.Request.Method,
+				"path":    context.FullPath(),
+			},
+		})
+	}
 
 	// Update last used timestamp
 	defer func() {
@@ -67,3 +140,83 @@ func (service *AuthenticationMiddleware) isAuthenticated(context *gin.Context, o
 
 	return true
 }
+
+// isApiKeyAuthenticated validates a personal access token and sets the owner context information.
+func (service *AuthenticationMiddleware) isApiKeyAuthenticated(context *gin.Context, key string, ownerType string) bool {
+	apiKey, err := service.ApiKeyService.Validate(key, ownerType)
+	if err != nil {
+		response.Api(context).SetMessage(errs.ErrAuthenticationFailed.Error()).SetStatusCode(http.StatusUnauthorized).SetLog().Send()
+		return false
+	}
+
+	// Api keys of deactivated or deleted owners are no longer honoured.
+	if err = service.AccessTokenService.CheckOwnerIsActive(apiKey.OwnerID, apiKey.OwnerType); err != nil {
+		response.Api(context).SetMessage(authenticationFailureMessage(err)).SetStatusCode(http.StatusUnauthorized).SetLog().Send()
+		return false
+	}
+
+	// An api key carries the permissions of its owner, narrowed down to its scopes when it has any.
+	permissions, err := service.AuthorizationService.GetOwnerPermissions(apiKey.OwnerID, apiKey.OwnerType)
+	if err != nil {
+		response.Api(context).SetMessage(errs.ErrAuthenticationFailed.Error()).SetStatusCode(http.StatusUnauthorized).SetLog().Send()
+		return false
+	}
+	if len(apiKey.Scopes) > 0 {
+		permissions = slices.DeleteFunc(permissions, func(permission string) bool {
+			return !slices.Contains(apiKey.Scopes, permission)
+		})
+	}
+
+	// Api key is valid, set user context
+	context.Set("authenticated-user-id", apiKey.OwnerID)
+	context.Set("authenticated-user-type", apiKey.OwnerType)
+	context.Set("api-key-uuid", apiKey.Uuid.String())
+	context.Set("authenticated-scopes", []string(apiKey.Scopes))
+	context.Set("authenticated-permissions", permissions)
+	context.Set("authentication-method", "api-key")
+
+	// Update last used timestamp
+	defer func() {
+		_, _ = service.ApiKeyService.UpdateLastUsedAt(apiKey)
+	}()
+
+	return true
+}
+
+// DPoP verifies the DPoP proof sent to a token endpoint, the issued tokens are then bound to its key.
+// Requests without a proof keep getting bearer tokens.
+func (service *AuthenticationMiddleware) DPoP(context *gin.Context) {
+	proof := context.GetHeader("DPoP")
+	if proof == "" {
+		context.Next()
+		return
+	}
+
+	thumbprint, err := service.DPoPService.Verify(proof, context.Request.Method, context.Request.URL.Path, "")
+	if err != nil {
+		response.Api(context).SetMessage(err.Error()).SetStatusCode(http.StatusBadRequest).SetLog().Send()
+		context.Abort()
+		return
+	}
+
+	context.Set("dpop-jkt", thumbprint)
+	context.Next()
+}
+
+// hasProofOfPossession checks the DPoP proof of the request was made for the access token by the key it is bound to.
+func (service *AuthenticationMiddleware) hasProofOfPossession(context *gin.Context, tokenString, thumbprint string) bool {
+	if thumbprint == "" {
+		return false
+	}
+
+	proofThumbprint, err := service.DPoPService.Verify(context.GetHeader("DPoP"), context.Request.Method, context.Request.URL.Path, tokenString)
+	return err == nil && proofThumbprint == thumbprint
+}
+
+// authenticationFailureMessage tells deactivated owners why they were rejected and hides every other reason.
+func authenticationFailureMessage(err error) string {
+	if errors.Is(err, errs.ErrUserIsNotActive) || errors.Is(err, errs.ErrAdminIsNotActive) || errors.Is(err, errs.ErrSessionExpired) {
+		return err.Error()
+	}
+	return errs.ErrAuthenticationFailed.Error()
+}
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	response "go-auth-otp-service/src/api/http/responses"
+	"net/http"
+	"slices"
+)
+
+// RequirePermission aborts the request unless the authenticated owner holds every given permission.
+// It relies on the permissions set by the AuthenticationMiddleware, so it must be attached after it.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		granted := context.GetStringSlice("authenticated-permissions")
+		for _, permission := range permissions {
+			if !slices.Contains(granted, permission) {
+				response.Api(context).SetMessage(errs.ErrPermissionDenied.Error()).SetStatusCode(http.StatusForbidden).SetLog().Send()
+				context.Abort()
+				return
+			}
+		}
+
+		context.Next()
+	}
+}
@@ -0,0 +1,21 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/geoip"
+	"net/http"
+)
+
+// GeoBlock refuses the request when the client ip is located in one of the blocked countries.
+// It relies on the ip set by RequestContext.
+func GeoBlock(context *gin.Context) {
+	if geoip.GetInstance().IsBlocked(context.GetString("request-ip")) {
+		response.Api(context).SetMessage(errs.ErrCountryBlocked.Error()).SetStatusCode(http.StatusForbidden).SetLog().Send()
+		context.Abort()
+		return
+	}
+
+	context.Next()
+}
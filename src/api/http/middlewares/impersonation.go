@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	response "go-auth-otp-service/src/api/http/responses"
+	"net/http"
+)
+
+// DenyImpersonation aborts the request when an admin is impersonating the owner, it guards the routes
+// which change how the owner signs in. It relies on the AuthenticationMiddleware, so it must be attached after it.
+func DenyImpersonation(context *gin.Context) {
+	if context.GetUint("impersonator-id") != 0 {
+		response.Api(context).SetMessage(errs.ErrImpersonationDenied.Error()).SetStatusCode(http.StatusForbidden).SetLog().Send()
+		context.Abort()
+		return
+	}
+
+	context.Next()
+}
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	response "go-auth-otp-service/src/api/http/responses"
+	"net/http"
+	"time"
+)
+
+// RequireRecentAuth aborts the request unless the session was authenticated within maxAge,
+// either on login or through the step-up endpoints. Api keys and impersonation sessions never pass it.
+// It relies on the auth time set by the AuthenticationMiddleware, so it must be attached after it.
+func RequireRecentAuth(maxAge time.Duration) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		authTime := context.GetTime("auth-time")
+		if authTime.IsZero() || time.Since(authTime) > maxAge || context.GetUint("impersonator-id") != 0 {
+			response.Api(context).SetMessage(errs.ErrRecentAuthRequired.Error()).
+				SetStatusCode(http.StatusUnauthorized).
+				SetData(map[string]interface{}{
+					"max_age": int(maxAge.Seconds()),
+				}).
+				SetLog().
+				Send()
+			context.Abort()
+			return
+		}
+
+		context.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+)
+
+// RequestContext tags every request with a uuid and the client ip, services record both in the audit log.
+// A valid X-Request-ID header sent by a proxy is kept, the uuid is echoed back in the same header.
+func RequestContext(context *gin.Context) {
+	requestUuid, err := uuid.Parse(context.GetHeader("X-Request-ID"))
+	if err != nil {
+		requestUuid = uuid.New()
+	}
+
+	context.Set("request-uuid", requestUuid.String())
+	context.Set("request-ip", context.ClientIP())
+	context.Header("X-Request-ID", requestUuid.String())
+
+	context.Next()
+}
+
+// ServiceContext carries the request information and the authenticated actor into the context passed to services.
+func ServiceContext(c *gin.Context) context.Context {
+	ctx := context.WithValue(context.Background(), "request-ip", c.GetString("request-ip"))
+	ctx = context.WithValue(ctx, "request-user-agent", c.GetHeader("User-Agent"))
+	ctx = context.WithValue(ctx, "request-device-name", c.GetHeader("X-Device-Name"))
+	ctx = context.WithValue(ctx, "request-device-platform", c.GetHeader("X-Device-Platform"))
+	ctx = context.WithValue(ctx, "request-app-version", c.GetHeader("X-App-Version"))
+	ctx = context.WithValue(ctx, "request-uuid", c.GetString("request-uuid"))
+	ctx = context.WithValue(ctx, "locale", c.GetString("locale"))
+	ctx = context.WithValue(ctx, "actor-id", c.GetUint("authenticated-user-id"))
+	ctx = context.WithValue(ctx, "actor-type", c.GetString("authenticated-user-type"))
+	// the admin impersonating the owner is the one acting
+	if impersonatorID := c.GetUint("impersonator-id"); impersonatorID != 0 {
+		ctx = context.WithValue(ctx, "actor-id", impersonatorID)
+		ctx = context.WithValue(ctx, "actor-type", "admin")
+	}
+	ctx = context.WithValue(ctx, "dpop-jkt", c.GetString("dpop-jkt"))
+	return ctx
+}
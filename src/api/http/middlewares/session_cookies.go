@@ -0,0 +1,112 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/pkg/utils"
+	"go-auth-otp-service/src/services/authentication"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CSRFCookie holds the double-submit csrf token, browsers echo it in the CSRFHeader of mutating requests.
+const (
+	CSRFCookie = "csrf_token"
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// refreshCookiePaths scope the refresh token cookies to the refresh endpoint of each owner type.
+var refreshCookiePaths = map[string]string{
+	"user":  "/api/v1/access-tokens/refresh",
+	"admin": "/api/v1/admin/authentication/refresh",
+}
+
+// SessionCookieMode reports whether SESSION_COOKIE_MODE is enabled, the tokens are then also handed to browsers
+// in http only cookies and read back from them.
+func SessionCookieMode() bool {
+	return config.GetInstance().Get("SESSION_COOKIE_MODE") == "true"
+}
+
+// SetSessionCookies stores the tokens of the owner type in http only cookies, along with a new csrf token
+// readable by the web app. It does nothing unless the session cookie mode is enabled.
+func SetSessionCookies(c *gin.Context, ownerType string, jwt *authentication.JwtDTO) {
+	if !SessionCookieMode() || jwt == nil {
+		return
+	}
+
+	csrfToken, err := utils.GenerateSalt(32)
+	if err != nil {
+		return
+	}
+
+	setCookie(c, accessTokenCookie(ownerType), jwt.AccessTokenString, "/api/v1", jwt.AccessTokenExpiresAt, true)
+	setCookie(c, refreshTokenCookie(ownerType), jwt.RefreshTokenString, refreshCookiePaths[ownerType], jwt.RefreshTokenExpiresAt, true)
+	setCookie(c, CSRFCookie, base64.RawURLEncoding.EncodeToString(csrfToken), "/", jwt.RefreshTokenExpiresAt, false)
+}
+
+// ClearSessionCookies removes the cookies set by SetSessionCookies.
+func ClearSessionCookies(c *gin.Context, ownerType string) {
+	if !SessionCookieMode() {
+		return
+	}
+
+	setCookie(c, accessTokenCookie(ownerType), "", "/api/v1", time.Unix(0, 0), true)
+	setCookie(c, refreshTokenCookie(ownerType), "", refreshCookiePaths[ownerType], time.Unix(0, 0), true)
+	setCookie(c, CSRFCookie, "", "/", time.Unix(0, 0), false)
+}
+
+// RefreshTokenFromCookie returns the refresh token cookie of the owner type, once the csrf token is verified.
+// An empty token is returned without error when there is no such cookie.
+func RefreshTokenFromCookie(c *gin.Context, ownerType string) (string, error) {
+	if !SessionCookieMode() {
+		return "", nil
+	}
+
+	token, err := c.Cookie(refreshTokenCookie(ownerType))
+	if err != nil || token == "" {
+		return "", nil
+	}
+	if !VerifyCSRF(c) {
+		return "", errs.ErrInvalidCSRFToken
+	}
+	return token, nil
+}
+
+// VerifyCSRF compares the csrf header with the csrf cookie on the mutating requests, safe methods always pass.
+func VerifyCSRF(c *gin.Context) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	cookie, err := c.Cookie(CSRFCookie)
+	header := c.GetHeader(CSRFHeader)
+	if err != nil || cookie == "" || header == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) == 1
+}
+
+func accessTokenCookie(ownerType string) string {
+	return ownerType + "_access_token"
+}
+
+func refreshTokenCookie(ownerType string) string {
+	return ownerType + "_refresh_token"
+}
+
+// setCookie writes a strict same site cookie, secure when the app is served over https.
+func setCookie(c *gin.Context, name, value, path string, expiresAt time.Time, httpOnly bool) {
+	maxAge := int(time.Until(expiresAt).Seconds())
+	if maxAge <= 0 {
+		maxAge = -1
+	}
+
+	secure := strings.HasPrefix(config.GetInstance().Get("APP_URL"), "https://")
+	c.SetSameSite(http.SameSiteStrictMode)
+	c.SetCookie(name, value, maxAge, path, config.GetInstance().Get("COOKIE_DOMAIN"), secure, httpOnly)
+}
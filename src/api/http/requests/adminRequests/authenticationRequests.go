@@ -0,0 +1,18 @@
+package adminRequests
+
+// LoginRequest struct for validating the first step of the admin login
+type LoginRequest struct {
+	Username string `json:"username" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,max=255"`
+}
+
+// VerifyLoginRequest struct for validating the second step of the admin login
+type VerifyLoginRequest struct {
+	Key  string `json:"key" validate:"required"`
+	Code string `json:"code" validate:"required,numeric"`
+}
+
+// EnableTotpRequest struct for validating the code confirming a totp enrollment
+type EnableTotpRequest struct {
+	Code string `json:"code" validate:"required,numeric"`
+}
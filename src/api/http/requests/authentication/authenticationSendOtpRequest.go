@@ -7,6 +7,8 @@ type AuthSendOtpRequest struct {
 }
 
 type AuthVerifyOTP struct {
-	Key string `json:"key" validate:"omitempty"`
-	OTP string `json:"otp" validate:"required"`
+	Key            string `json:"key" validate:"omitempty"`
+	OTP            string `json:"otp" validate:"required"`
+	RememberDevice bool   `json:"remember_device"`
+	DeviceID       string `json:"device_id" validate:"required_if=RememberDevice true,max=255"`
 }
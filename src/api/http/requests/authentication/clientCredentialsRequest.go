@@ -0,0 +1,9 @@
+package authentication
+
+// ClientCredentialsRequest is bound from a json or an application/x-www-form-urlencoded body.
+type ClientCredentialsRequest struct {
+	GrantType    string `json:"grant_type" form:"grant_type" validate:"required"`
+	ClientID     string `json:"client_id" form:"client_id" validate:"required"`
+	ClientSecret string `json:"client_secret" form:"client_secret" validate:"required"`
+	Scope        string `json:"scope" form:"scope" validate:"omitempty"`
+}
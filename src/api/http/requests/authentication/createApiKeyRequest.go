@@ -0,0 +1,9 @@
+package authentication
+
+import "time"
+
+type CreateApiKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=255"`
+	Scopes    []string   `json:"scopes" validate:"omitempty,dive,required,max=100"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitempty,gt"`
+}
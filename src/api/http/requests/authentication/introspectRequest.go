@@ -0,0 +1,5 @@
+package authentication
+
+type IntrospectRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,5 @@
+package authentication
+
+type StepUpVerifyRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+}
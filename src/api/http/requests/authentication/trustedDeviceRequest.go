@@ -0,0 +1,7 @@
+package authentication
+
+type TrustedDeviceLoginRequest struct {
+	Mobile   string `json:"mobile" validate:"required,iranian-mobile"`
+	DeviceID string `json:"device_id" validate:"required,max=255"`
+	Token    string `json:"token" validate:"omitempty"`
+}
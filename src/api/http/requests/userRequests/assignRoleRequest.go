@@ -0,0 +1,6 @@
+package userRequests
+
+// AssignRoleRequest struct for validating incoming request data for assigning a role to a user
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required,max=100"`
+}
@@ -11,3 +11,13 @@ type CreateRequest struct {
 	Mobile               string    `json:"mobile" validate:"required,e164"`
 	Password             string    `json:"password" validate:"required,max=255,is-strong-password"`
 }
+
+// AdminCreateRequest struct for validating incoming request data for creating a user by an admin
+type AdminCreateRequest struct {
+	FirstName            string `json:"first_name" validate:"omitempty,max=255"`
+	LastName             string `json:"last_name" validate:"omitempty,max=255"`
+	FatherName           string `json:"father_name" validate:"omitempty,max=255"`
+	NationalIdentityCode string `json:"national_identity_code" validate:"omitempty,iranian-national-identity-code"`
+	Mobile               string `json:"mobile" validate:"required,iranian-mobile"`
+	Email                string `json:"email" validate:"omitempty,email,max=100"`
+}
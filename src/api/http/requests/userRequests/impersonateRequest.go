@@ -0,0 +1,6 @@
+package userRequests
+
+// ImpersonateRequest struct for validating incoming request data for impersonating a user by an admin
+type ImpersonateRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
@@ -0,0 +1,22 @@
+package userRequests
+
+// UpdateRequest struct for validating incoming request data for updating a user,
+// nil fields are left untouched.
+type UpdateRequest struct {
+	FirstName            *string `json:"first_name" validate:"omitempty,max=255"`
+	LastName             *string `json:"last_name" validate:"omitempty,max=255"`
+	FatherName           *string `json:"father_name" validate:"omitempty,max=255"`
+	NationalIdentityCode *string `json:"national_identity_code" validate:"omitempty,iranian-national-identity-code"`
+	Mobile               *string `json:"mobile" validate:"omitempty,iranian-mobile"`
+	Email                *string `json:"email" validate:"omitempty,email,max=100"`
+}
+
+// UpdateProfileRequest struct for validating incoming request data for updating the authenticated user's profile,
+// nil fields are left untouched.
+type UpdateProfileRequest struct {
+	FirstName            *string `json:"first_name" validate:"omitempty,max=255"`
+	LastName             *string `json:"last_name" validate:"omitempty,max=255"`
+	FatherName           *string `json:"father_name" validate:"omitempty,max=255"`
+	NationalIdentityCode *string `json:"national_identity_code" validate:"omitempty,iranian-national-identity-code"`
+	Email                *string `json:"email" validate:"omitempty,email,max=100"`
+}
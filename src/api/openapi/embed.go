@@ -0,0 +1,13 @@
+package openapi
+
+import _ "embed"
+
+// Spec is the generated document, regenerated with "app openapi generate".
+//
+//go:embed openapi.json
+var Spec []byte
+
+// SwaggerUI is the page browsing the document.
+//
+//go:embed swagger.html
+var SwaggerUI []byte
@@ -0,0 +1,264 @@
+// Package openapi describes the http api in an OpenAPI 3 document built from the routes registered on the router,
+// the documented Routes and the request structs they bind. The document is generated into openapi.json,
+// which is embedded and served, and checked against the code by the "app openapi check" command.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	response "go-auth-otp-service/src/api/http/responses"
+	"go-auth-otp-service/src/pkg/i18n"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	version     = "3.0.3"
+	apiVersion  = "1.0.0"
+	envelopeRef = "#/components/schemas/Response"
+)
+
+// paginationParameters are the query parameters of the lists, read by the QueryParametersBuilderMiddleware.
+var paginationParameters = []string{"page", "page_size", "sort_by", "sort_order", "created_after", "created_before", "filters", "likes"}
+
+var securitySchemes = map[string]*SecurityScheme{
+	"user": {Type: "http", Scheme: "bearer", BearerFormat: "JWT",
+		Description: "Access token or api key of a user. Tokens bound to a DPoP key are sent with the DPoP scheme and a proof, " +
+			"browsers in the session cookie mode send the cookies and the X-CSRF-Token header instead."},
+	"admin": {Type: "http", Scheme: "bearer", BearerFormat: "JWT",
+		Description: "Access token of an admin."},
+	"client": {Type: "http", Scheme: "bearer", BearerFormat: "JWT",
+		Description: "Access token of a service client, issued by the client_credentials grant."},
+}
+
+// Generate describes the routes registered on the router. It fails when a registered route is not documented in
+// Routes or a documented route is not registered, so the document never drifts from the router.
+func Generate(registered gin.RoutesInfo) (*Document, error) {
+	s := newSchemas()
+	s.ref(reflect.TypeOf(response.Response{}))
+
+	document := &Document{
+		OpenAPI: version,
+		Info: Info{
+			Title: "go-auth-otp-service",
+			Description: "Every response, successful or not, is the Response envelope. " +
+				"Validation errors of the fields are listed in its errors.",
+			Version: apiVersion,
+		},
+		Tags:  tags,
+		Paths: map[string]*PathItem{},
+		Components: Components{
+			Schemas:         s.components,
+			Parameters:      parameters(),
+			SecuritySchemes: securitySchemes,
+		},
+	}
+
+	var undocumented, unregistered []string
+	seen := map[string]bool{}
+	for _, route := range registered {
+		key := route.Method + " " + route.Path
+		documented, ok := Routes[key]
+		if !ok {
+			undocumented = append(undocumented, key)
+			continue
+		}
+		seen[key] = true
+
+		path := openapiPath(route.Path)
+		item, ok := document.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			document.Paths[path] = item
+		}
+
+		operation := documented.operation(s, route.Method, route.Path)
+		switch route.Method {
+		case "GET":
+			item.Get = operation
+		case "PUT":
+			item.Put = operation
+		case "POST":
+			item.Post = operation
+		case "DELETE":
+			item.Delete = operation
+		case "PATCH":
+			item.Patch = operation
+		}
+	}
+	for key := range Routes {
+		if !seen[key] {
+			unregistered = append(unregistered, key)
+		}
+	}
+
+	if len(undocumented) > 0 || len(unregistered) > 0 {
+		sort.Strings(undocumented)
+		sort.Strings(unregistered)
+		return nil, fmt.Errorf("openapi: routes not documented in openapi.Routes: [%s], documented routes not registered: [%s]",
+			strings.Join(undocumented, ", "), strings.Join(unregistered, ", "))
+	}
+	return document, nil
+}
+
+// Marshal renders the document the way openapi.json is stored.
+func Marshal(document *Document) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// operation describes the route.
+func (route Route) operation(s *schemas, method, path string) *Operation {
+	operation := &Operation{
+		Tags:        []string{route.Tag},
+		Summary:     route.Summary,
+		OperationID: operationID(method, path),
+		Parameters:  []*Parameter{{Ref: "#/components/parameters/Accept-Language"}},
+		Permissions: route.Permissions,
+	}
+
+	var description []string
+	if len(route.Permissions) > 0 {
+		description = append(description, "Requires the "+strings.Join(route.Permissions, ", ")+" permissions.")
+	}
+	if route.RecentAuth {
+		description = append(description, "Requires the session to have stepped up within the last 10 minutes.")
+	}
+	operation.Description = strings.Join(description, " ")
+
+	if route.Auth != "" {
+		operation.Security = []map[string][]string{{route.Auth: {}}}
+	}
+
+	// path parameters
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := pathParameter(segment); ok {
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+
+	// query parameters
+	for _, name := range route.Query {
+		operation.Parameters = append(operation.Parameters, &Parameter{Name: name, In: "query", Schema: &Schema{Type: "string"}})
+	}
+	if route.Paginated {
+		for _, name := range paginationParameters {
+			operation.Parameters = append(operation.Parameters, &Parameter{Ref: "#/components/parameters/" + name})
+		}
+	}
+
+	// body
+	switch {
+	case route.Request != nil:
+		schema := s.ref(reflect.TypeOf(route.Request))
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: schema}},
+		}
+		if route.Form {
+			operation.RequestBody.Content["application/x-www-form-urlencoded"] = &MediaType{Schema: schema}
+		}
+	case route.Upload != "":
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{"multipart/form-data": {Schema: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{route.Upload: {Type: "string", Format: "binary"}},
+				Required:   []string{route.Upload},
+			}}},
+		}
+	}
+
+	// responses
+	status := route.Status
+	if status == 0 {
+		status = 200
+	}
+	success := &Response{Description: "Successful.", Content: map[string]*MediaType{}}
+	if len(route.Produces) == 0 {
+		success.Content["application/json"] = &MediaType{Schema: &Schema{Ref: envelopeRef}}
+	}
+	for _, mediaType := range route.Produces {
+		success.Content[mediaType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	operation.Responses = map[string]*Response{
+		strconv.Itoa(status): success,
+		"default": {
+			Description: "The error, with the validation errors of the fields.",
+			Content:     map[string]*MediaType{"application/json": {Schema: &Schema{Ref: envelopeRef}}},
+		},
+	}
+
+	return operation
+}
+
+// parameters are the parameters shared by the operations.
+func parameters() map[string]*Parameter {
+	shared := map[string]*Parameter{
+		"Accept-Language": {Name: "Accept-Language", In: "header", Description: "The locale of the messages.",
+			Schema: &Schema{Type: "string", Enum: i18n.Locales}},
+		"page":           {Name: "page", In: "query", Schema: &Schema{Type: "integer", Format: "int32"}},
+		"page_size":      {Name: "page_size", In: "query", Schema: &Schema{Type: "integer", Format: "int32"}},
+		"sort_by":        {Name: "sort_by", In: "query", Schema: &Schema{Type: "string"}},
+		"sort_order":     {Name: "sort_order", In: "query", Schema: &Schema{Type: "string", Enum: []string{"asc", "desc"}}},
+		"created_after":  {Name: "created_after", In: "query", Schema: &Schema{Type: "string", Format: "date-time"}},
+		"created_before": {Name: "created_before", In: "query", Schema: &Schema{Type: "string", Format: "date-time"}},
+		"filters": {Name: "filters", In: "query", Style: "deepObject", Explode: true,
+			Description: "Exact matches of the filterable fields, like filters[platform]=ios.",
+			Schema:      &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}},
+		"likes": {Name: "likes", In: "query", Style: "deepObject", Explode: true,
+			Description: "Partial matches of the searchable fields, like likes[first_name]=ali.",
+			Schema:      &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}},
+	}
+	return shared
+}
+
+// openapiPath turns the :name and *name parameters of gin into {name}.
+func openapiPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := pathParameter(segment); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParameter(segment string) (string, bool) {
+	if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+		return segment[1:], true
+	}
+	return "", false
+}
+
+// operationID names the operation after its method and path, like deleteAccessTokensRevokeByUuid.
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/api/v1"), "/") {
+		name, isParameter := pathParameter(segment)
+		if !isParameter {
+			name = segment
+		} else {
+			id += "By"
+		}
+		for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			id += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return id
+}
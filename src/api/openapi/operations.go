@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"go-auth-otp-service/src/api/http/requests/adminRequests"
+	authRequests "go-auth-otp-service/src/api/http/requests/authentication"
+	"go-auth-otp-service/src/api/http/requests/userRequests"
+	"go-auth-otp-service/src/pkg/permissions"
+)
+
+// Route documents a route, the routes registered on the router and the ones listed here must match.
+type Route struct {
+	Tag     string
+	Summary string
+	// Auth is the owner type of the token the route requires, empty for the public routes.
+	Auth        string
+	Permissions []string
+	// RecentAuth tells the session must have stepped up within the last 10 minutes.
+	RecentAuth bool
+	// Request is the zero value of the json body the route binds.
+	Request interface{}
+	// Form tells the route also binds the Request from an application/x-www-form-urlencoded body.
+	Form bool
+	// Upload is the form field of the file the route receives.
+	Upload    string
+	Query     []string
+	Paginated bool
+	// Status is the status code of the successful response, 200 when zero.
+	Status int
+	// Produces are the media types the route responds with instead of the json envelope.
+	Produces []string
+}
+
+// tags describe the groups of routes, in the order they are listed.
+var tags = []Tag{
+	{Name: "authentication", Description: "OTP login, trusted devices, step-up and service tokens."},
+	{Name: "access-tokens", Description: "The sessions of the authenticated user."},
+	{Name: "api-keys", Description: "Personal access tokens of the authenticated user."},
+	{Name: "users", Description: "The account of the authenticated user and the users."},
+	{Name: "authorization", Description: "Roles and permissions."},
+	{Name: "admin", Description: "The admin panel."},
+	{Name: "storage", Description: "Files of the local storage driver."},
+	{Name: "docs", Description: "This document."},
+}
+
+// Routes lists the documented routes by method and path.
+var Routes = map[string]Route{
+	// authentication
+	"POST /api/v1/authentication/register/send-otp": {Tag: "authentication", Summary: "Send an OTP to the mobile of a user",
+		Request: authRequests.AuthSendOtpRequest{}},
+	"POST /api/v1/authentication/register/verify-otp": {Tag: "authentication", Summary: "Verify the OTP and open a session",
+		Request: authRequests.AuthVerifyOTP{}},
+	"POST /api/v1/authentication/trusted-device": {Tag: "authentication", Summary: "Open a session of a trusted device without an OTP",
+		Request: authRequests.TrustedDeviceLoginRequest{}},
+	"POST /api/v1/authentication/step-up/challenge": {Tag: "authentication", Summary: "Send the code re-authenticating the session",
+		Auth: "user"},
+	"POST /api/v1/authentication/step-up/verify": {Tag: "authentication", Summary: "Verify the code re-authenticating the session",
+		Auth: "user", Request: authRequests.StepUpVerifyRequest{}},
+	"GET /api/v1/authentication/sessions/:id/not-me": {Tag: "authentication", Summary: "Confirm the revocation of a new session from the link of its notification",
+		Query: []string{"expires", "signature"}, Produces: []string{"text/html"}},
+	"POST /api/v1/authentication/sessions/:id/not-me": {Tag: "authentication", Summary: "Revoke a new session and its trusted device from the link of its notification",
+		Query: []string{"expires", "signature"}},
+	"POST /api/v1/authentication/token": {Tag: "authentication", Summary: "Issue an access token to a client",
+		Request: authRequests.ClientCredentialsRequest{}, Form: true},
+	"POST /api/v1/authentication/introspect": {Tag: "authentication", Summary: "Describe the access token of a user",
+		Auth: "client", Request: authRequests.IntrospectRequest{}},
+
+	// access tokens
+	"GET /api/v1/access-tokens": {Tag: "access-tokens", Summary: "List the sessions",
+		Auth: "user", Paginated: true},
+	"GET /api/v1/access-tokens/:uuid": {Tag: "access-tokens", Summary: "Show a session",
+		Auth: "user"},
+	"POST /api/v1/access-tokens/refresh": {Tag: "access-tokens", Summary: "Refresh the tokens of a session",
+		Request: authRequests.RefreshAccessTokenRequest{}},
+	"DELETE /api/v1/access-tokens/revoke": {Tag: "access-tokens", Summary: "Revoke every session",
+		Auth: "user", RecentAuth: true},
+	"DELETE /api/v1/access-tokens/revoke/:uuid": {Tag: "access-tokens", Summary: "Revoke a session",
+		Auth: "user"},
+	"DELETE /api/v1/access-tokens/revoke/current-token": {Tag: "access-tokens", Summary: "Revoke the current session",
+		Auth: "user"},
+	"GET /api/v1/active-access-tokens": {Tag: "access-tokens", Summary: "List the sessions which have not expired",
+		Auth: "user", Paginated: true},
+
+	// api keys
+	"GET /api/v1/api-keys": {Tag: "api-keys", Summary: "List the api keys",
+		Auth: "user", Paginated: true},
+	"POST /api/v1/api-keys": {Tag: "api-keys", Summary: "Create an api key",
+		Auth: "user", Request: authRequests.CreateApiKeyRequest{}, Status: 201},
+	"DELETE /api/v1/api-keys/:uuid": {Tag: "api-keys", Summary: "Revoke an api key",
+		Auth: "user"},
+
+	// users
+	"GET /api/v1/users/me": {Tag: "users", Summary: "Show the profile",
+		Auth: "user"},
+	"PATCH /api/v1/users/me": {Tag: "users", Summary: "Update the profile",
+		Auth: "user", Request: userRequests.UpdateProfileRequest{}},
+	"DELETE /api/v1/users/me": {Tag: "users", Summary: "Delete the account after the grace period",
+		Auth: "user", RecentAuth: true},
+	"GET /api/v1/users/me/export": {Tag: "users", Summary: "Export the data of the account",
+		Auth: "user", RecentAuth: true, Query: []string{"format"}, Produces: []string{"application/json", "application/zip"}},
+	"GET /api/v1/users/me/trusted-devices": {Tag: "users", Summary: "List the trusted devices",
+		Auth: "user", Paginated: true},
+	"DELETE /api/v1/users/me/trusted-devices": {Tag: "users", Summary: "Revoke every trusted device",
+		Auth: "user", RecentAuth: true},
+	"DELETE /api/v1/users/me/trusted-devices/:uuid": {Tag: "users", Summary: "Revoke a trusted device",
+		Auth: "user"},
+	"GET /api/v1/users/me/audit-events": {Tag: "users", Summary: "List the audit events of the account",
+		Auth: "user", Paginated: true},
+	"GET /api/v1/users/me/profile-image": {Tag: "users", Summary: "Show the urls of the profile image",
+		Auth: "user"},
+	"POST /api/v1/users/me/profile-image": {Tag: "users", Summary: "Upload the profile image",
+		Auth: "user", Upload: "image"},
+	"DELETE /api/v1/users/me/profile-image": {Tag: "users", Summary: "Delete the profile image",
+		Auth: "user"},
+	"GET /api/v1/users": {Tag: "users", Summary: "List the users",
+		Auth: "user", Permissions: []string{permissions.UsersList}, Paginated: true},
+	"GET /api/v1/users/:uuid": {Tag: "users", Summary: "Show a user",
+		Auth: "user", Permissions: []string{permissions.UsersShow}},
+
+	// authorization
+	"GET /api/v1/roles": {Tag: "authorization", Summary: "List the roles",
+		Auth: "user", Permissions: []string{permissions.RolesList}, Paginated: true},
+	"GET /api/v1/permissions": {Tag: "authorization", Summary: "List the permissions",
+		Auth: "user", Permissions: []string{permissions.PermissionsList}, Paginated: true},
+	"GET /api/v1/users/:uuid/roles": {Tag: "authorization", Summary: "List the roles of a user",
+		Auth: "user", Permissions: []string{permissions.RolesList}},
+	"POST /api/v1/users/:uuid/roles": {Tag: "authorization", Summary: "Assign a role to a user",
+		Auth: "user", Permissions: []string{permissions.RolesAssign}, Request: userRequests.AssignRoleRequest{}},
+	"DELETE /api/v1/users/:uuid/roles/:role": {Tag: "authorization", Summary: "Revoke a role of a user",
+		Auth: "user", Permissions: []string{permissions.RolesAssign}},
+
+	// admin
+	"POST /api/v1/admin/authentication/login": {Tag: "admin", Summary: "Check the password of an admin",
+		Request: adminRequests.LoginRequest{}},
+	"POST /api/v1/admin/authentication/verify": {Tag: "admin", Summary: "Verify the second factor and open a session",
+		Request: adminRequests.VerifyLoginRequest{}},
+	"POST /api/v1/admin/authentication/refresh": {Tag: "admin", Summary: "Refresh the tokens of a session",
+		Request: authRequests.RefreshAccessTokenRequest{}},
+	"POST /api/v1/admin/authentication/step-up/challenge": {Tag: "admin", Summary: "Send the code re-authenticating the session",
+		Auth: "admin"},
+	"POST /api/v1/admin/authentication/step-up/verify": {Tag: "admin", Summary: "Verify the code re-authenticating the session",
+		Auth: "admin", Request: authRequests.StepUpVerifyRequest{}},
+	"GET /api/v1/admin/me": {Tag: "admin", Summary: "Show the profile",
+		Auth: "admin"},
+	"POST /api/v1/admin/totp/setup": {Tag: "admin", Summary: "Start the enrollment of a TOTP",
+		Auth: "admin", RecentAuth: true},
+	"POST /api/v1/admin/totp/enable": {Tag: "admin", Summary: "Confirm the enrollment of the TOTP",
+		Auth: "admin", RecentAuth: true, Request: adminRequests.EnableTotpRequest{}},
+	"GET /api/v1/admin/access-tokens": {Tag: "admin", Summary: "List the sessions",
+		Auth: "admin", Paginated: true},
+	"GET /api/v1/admin/active-access-tokens": {Tag: "admin", Summary: "List the sessions which have not expired",
+		Auth: "admin", Paginated: true},
+	"DELETE /api/v1/admin/access-tokens/revoke": {Tag: "admin", Summary: "Revoke every session",
+		Auth: "admin", RecentAuth: true},
+	"DELETE /api/v1/admin/access-tokens/revoke/:uuid": {Tag: "admin", Summary: "Revoke a session",
+		Auth: "admin"},
+	"DELETE /api/v1/admin/access-tokens/revoke/current-token": {Tag: "admin", Summary: "Revoke the current session",
+		Auth: "admin"},
+	"GET /api/v1/admin/users": {Tag: "admin", Summary: "List the users",
+		Auth: "admin", Permissions: []string{permissions.UsersList}, Paginated: true},
+	"GET /api/v1/admin/users/:uuid": {Tag: "admin", Summary: "Show a user",
+		Auth: "admin", Permissions: []string{permissions.UsersShow}},
+	"POST /api/v1/admin/users": {Tag: "admin", Summary: "Create a user",
+		Auth: "admin", Permissions: []string{permissions.UsersCreate}, Request: userRequests.AdminCreateRequest{}, Status: 201},
+	"PATCH /api/v1/admin/users/:uuid": {Tag: "admin", Summary: "Update a user",
+		Auth: "admin", Permissions: []string{permissions.UsersUpdate}, Request: userRequests.UpdateRequest{}},
+	"POST /api/v1/admin/users/:uuid/deactivate": {Tag: "admin", Summary: "Deactivate a user and revoke the sessions",
+		Auth: "admin", Permissions: []string{permissions.UsersDeactivate}},
+	"POST /api/v1/admin/users/:uuid/reactivate": {Tag: "admin", Summary: "Reactivate a user",
+		Auth: "admin", Permissions: []string{permissions.UsersDeactivate}},
+	"DELETE /api/v1/admin/users/:uuid": {Tag: "admin", Summary: "Delete a user",
+		Auth: "admin", Permissions: []string{permissions.UsersDelete}},
+	"POST /api/v1/admin/users/:uuid/restore": {Tag: "admin", Summary: "Restore a deleted user",
+		Auth: "admin", Permissions: []string{permissions.UsersRestore}},
+	"DELETE /api/v1/admin/users/:uuid/force": {Tag: "admin", Summary: "Delete a user permanently",
+		Auth: "admin", Permissions: []string{permissions.UsersForceDelete}, RecentAuth: true},
+	"POST /api/v1/admin/users/:uuid/impersonate": {Tag: "admin", Summary: "Sign in as a user",
+		Auth: "admin", Permissions: []string{permissions.UsersImpersonate}, RecentAuth: true, Request: userRequests.ImpersonateRequest{}, Status: 201},
+	"GET /api/v1/admin/users/:uuid/sessions": {Tag: "admin", Summary: "List the sessions of a user",
+		Auth: "admin", Permissions: []string{permissions.SessionsList}, Paginated: true},
+	"DELETE /api/v1/admin/users/:uuid/sessions": {Tag: "admin", Summary: "Revoke every session of a user",
+		Auth: "admin", Permissions: []string{permissions.SessionsRevoke}},
+	"DELETE /api/v1/admin/users/:uuid/sessions/:session": {Tag: "admin", Summary: "Revoke a session of a user",
+		Auth: "admin", Permissions: []string{permissions.SessionsRevoke}},
+	"GET /api/v1/admin/audit-events": {Tag: "admin", Summary: "List the audit events",
+		Auth: "admin", Permissions: []string{permissions.AuditEventsList}, Paginated: true},
+	"GET /api/v1/admin/roles": {Tag: "admin", Summary: "List the roles",
+		Auth: "admin", Permissions: []string{permissions.RolesList}, Paginated: true},
+	"GET /api/v1/admin/permissions": {Tag: "admin", Summary: "List the permissions",
+		Auth: "admin", Permissions: []string{permissions.PermissionsList}, Paginated: true},
+	"GET /api/v1/admin/users/:uuid/roles": {Tag: "admin", Summary: "List the roles of a user",
+		Auth: "admin", Permissions: []string{permissions.RolesList}},
+	"POST /api/v1/admin/users/:uuid/roles": {Tag: "admin", Summary: "Assign a role to a user",
+		Auth: "admin", Permissions: []string{permissions.RolesAssign}, Request: userRequests.AssignRoleRequest{}},
+	"DELETE /api/v1/admin/users/:uuid/roles/:role": {Tag: "admin", Summary: "Revoke a role of a user",
+		Auth: "admin", Permissions: []string{permissions.RolesAssign}},
+
+	// storage
+	"GET /api/v1/storage/*path": {Tag: "storage", Summary: "Download a file through its signed url",
+		Query: []string{"expires", "signature"}, Produces: []string{"application/octet-stream"}},
+
+	// docs
+	"GET /api/v1/openapi.json": {Tag: "docs", Summary: "This document",
+		Produces: []string{"application/json"}},
+	"GET /api/v1/docs": {Tag: "docs", Summary: "Browse this document with Swagger UI",
+		Produces: []string{"text/html"}},
+}
@@ -0,0 +1,230 @@
+package openapi
+
+import (
+	"github.com/google/uuid"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// rulePatterns are the patterns of the validation rules checking the format of strings.
+var rulePatterns = map[string]string{
+	"numeric":                        `^[0-9]+$`,
+	"e164":                           `^\+[1-9][0-9]{1,14}$`,
+	"iranian-mobile":                 `^09[0-9]{9}$`,
+	"iranian-national-identity-code": `^[0-9]{8,10}$`,
+}
+
+// ruleFormats are the formats of the validation rules checking the format of strings.
+var ruleFormats = map[string]string{
+	"email":      "email",
+	"url":        "uri",
+	"uri":        "uri",
+	"uuid":       "uuid",
+	"is-uuid":    "uuid",
+	"is-rfc3339": "date-time",
+}
+
+// schemas builds the schemas of the go types, named structs are added to the components once and referenced.
+type schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+// ref returns the reference to the component of the struct, building it on first use.
+func (s *schemas) ref(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	name, ok := s.names[t]
+	if !ok {
+		name = t.Name()
+		// structs of different packages may share a name
+		if _, taken := s.components[name]; taken {
+			name = path.Base(t.PkgPath()) + name
+		}
+		s.names[t] = name
+		s.components[name] = &Schema{}
+		*s.components[name] = *s.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// schemaOf returns the schema of the type.
+func (s *schemas) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schemaOf(t.Elem())}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return &Schema{Type: "object", AdditionalProperties: true}
+		}
+		return &Schema{Type: "object", AdditionalProperties: s.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		return s.ref(t)
+	}
+	// interfaces may hold anything
+	return &Schema{}
+}
+
+// structSchema describes the json fields of the struct with the constraints of their validate tags.
+func (s *schemas) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// the fields of embedded structs are promoted
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded := s.structSchema(field.Type)
+			for property, propertySchema := range embedded.Properties {
+				schema.Properties[property] = propertySchema
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.schemaOf(field.Type)
+		if tag := field.Tag.Get("validate"); tag != "" {
+			if applyRules(property, tag, func(name string) string { return jsonName(t, name) }) {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// jsonName is the json name of the field of the struct, the validate tags refer to the fields by their go names.
+func jsonName(t reflect.Type, name string) string {
+	field, ok := t.FieldByName(name)
+	if !ok {
+		return name
+	}
+	if tagged, _, _ := strings.Cut(field.Tag.Get("json"), ","); tagged != "" && tagged != "-" {
+		return tagged
+	}
+	return name
+}
+
+// applyRules sets the constraints of the validate tag on the schema and reports whether the field is required.
+// The rules after dive apply to the items, the whole tag is kept in x-validate.
+func applyRules(schema *Schema, tag string, jsonName func(field string) string) (required bool) {
+	schema.Validate = tag
+
+	target := schema
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "dive":
+			if target.Items == nil {
+				return required
+			}
+			target = target.Items
+		case "min", "max", "len":
+			setBound(target, name, param)
+		case "oneof":
+			target.Enum = strings.Fields(param)
+		case "required_if":
+			field, value, _ := strings.Cut(param, " ")
+			target.Description = strings.TrimSpace(target.Description + " Required when " + jsonName(field) + " is " + value + ".")
+		default:
+			if pattern, ok := rulePatterns[name]; ok {
+				target.Pattern = pattern
+			}
+			if format, ok := ruleFormats[name]; ok {
+				target.Format = format
+			}
+		}
+	}
+	return required
+}
+
+// setBound sets the min, max or len rule as the length of strings, the size of arrays or the value of numbers.
+func setBound(schema *Schema, rule, param string) {
+	bound, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	size := int(bound)
+
+	switch schema.Type {
+	case "string":
+		if rule != "max" {
+			schema.MinLength = &size
+		}
+		if rule != "min" {
+			schema.MaxLength = &size
+		}
+	case "array":
+		if rule != "max" {
+			schema.MinItems = &size
+		}
+		if rule != "min" {
+			schema.MaxItems = &size
+		}
+	case "integer", "number":
+		if rule != "max" {
+			schema.Minimum = &bound
+		}
+		if rule != "min" {
+			schema.Maximum = &bound
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"go-auth-otp-service/src/api/http/middlewares"
 	"go-auth-otp-service/src/models"
 	"go-auth-otp-service/src/providers"
+	"time"
 )
 
 func RegisterAccessTokensRouter(router *gin.RouterGroup) {
@@ -20,13 +21,14 @@ func RegisterAccessTokensRouter(router *gin.RouterGroup) {
 			authenticationContainer.AccessTokenController.GetByUuid,
 		)
 
-		accessTokens.POST("refresh", authenticationContainer.AccessTokenController.RefreshAccessToken)
+		accessTokens.POST("refresh", authenticationContainer.AuthenticationMiddleware.DPoP,
+			authenticationContainer.AccessTokenController.RefreshAccessToken)
 
 		revoke := accessTokens.Group("revoke").
 			Use(authenticationContainer.AuthenticationMiddleware.Middleware("user"))
 		{
-			revoke.DELETE("", authenticationContainer.AccessTokenController.RevokeTokens)
-			revoke.DELETE(":uuid", authenticationContainer.AccessTokenController.RevokeTokenByUUID)
+			revoke.DELETE("", middlewares.RequireRecentAuth(10*time.Minute), authenticationContainer.AccessTokenController.RevokeTokens)
+			revoke.DELETE(":uuid", middlewares.DenyImpersonation, authenticationContainer.AccessTokenController.RevokeTokenByUUID)
 			revoke.DELETE("current-token", authenticationContainer.AccessTokenController.RevokeCurrentToken)
 		}
 	}
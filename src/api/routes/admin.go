@@ -0,0 +1,131 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/http/middlewares"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/permissions"
+	"go-auth-otp-service/src/providers"
+	"go-auth-otp-service/src/services"
+	"time"
+)
+
+func AdminRouter(router *gin.RouterGroup) {
+	adminContainer := providers.GetAdminContainer()
+	authenticationContainer := providers.GetAuthenticationContainer()
+	userContainer := providers.GetUserContainer()
+	authorizationContainer := providers.GetAuthorizationContainer()
+
+	rateLimiterLogin := providers.ProvideRateLimiterMiddleware(
+		providers.ProvideRateLimiterService(),
+	).SetLimiter(services.AdminLoginCriticalLimiter()).SetKey(services.GenericCriticalKeyGetter("admin-login"))
+
+	rateLimiterVerifyLogin := providers.ProvideRateLimiterMiddleware(
+		providers.ProvideRateLimiterService(),
+	).SetLimiter(services.AdminLoginCriticalLimiter()).SetKey(services.GenericCriticalKeyGetter("admin-verify-login"))
+
+	rateLimiterStepUp := providers.ProvideRateLimiterMiddleware(
+		providers.ProvideRateLimiterService(),
+	).SetLimiter(services.AdminLoginCriticalLimiter()).SetKey(services.GenericCriticalKeyGetter("admin-step-up"))
+
+	// sensitive operations need the session to be authenticated again through the step-up endpoints
+	recentAuth := middlewares.RequireRecentAuth(10 * time.Minute)
+
+	// define route
+	admin := router.Group("admin")
+
+	// authentication
+	authentication := admin.Group("authentication")
+	{
+		authentication.POST("login", rateLimiterLogin.Middleware, middlewares.GeoBlock, adminContainer.AdminAuthenticationController.Login)
+		authentication.POST("verify", rateLimiterVerifyLogin.Middleware, middlewares.GeoBlock,
+			authenticationContainer.AuthenticationMiddleware.DPoP, adminContainer.AdminAuthenticationController.VerifyLogin)
+		authentication.POST("refresh", authenticationContainer.AuthenticationMiddleware.DPoP,
+			authenticationContainer.AccessTokenController.RefreshAdminAccessToken)
+	}
+
+	// every other admin route requires an admin token
+	authenticated := admin.Group("").
+		Use(authenticationContainer.AuthenticationMiddleware.Middleware("admin"))
+
+	// profile
+	{
+		authenticated.GET("me", adminContainer.AdminAuthenticationController.Me)
+		authenticated.POST("totp/setup", recentAuth, adminContainer.AdminAuthenticationController.SetupTotp)
+		authenticated.POST("totp/enable", recentAuth, adminContainer.AdminAuthenticationController.EnableTotp)
+		authenticated.POST("authentication/step-up/challenge", rateLimiterStepUp.Middleware,
+			authenticationContainer.StepUpController.Challenge)
+		authenticated.POST("authentication/step-up/verify", rateLimiterStepUp.Middleware,
+			authenticationContainer.StepUpController.Verify)
+	}
+
+	// own sessions
+	{
+		authenticated.GET("access-tokens", middlewares.QueryParametersBuilderMiddleware(models.AccessTokenModel{}),
+			authenticationContainer.AccessTokenController.GetList)
+		authenticated.GET("active-access-tokens", middlewares.QueryParametersBuilderMiddleware(models.AccessTokenModel{}),
+			authenticationContainer.AccessTokenController.GetActiveTokens)
+		authenticated.DELETE("access-tokens/revoke", recentAuth, authenticationContainer.AccessTokenController.RevokeTokens)
+		authenticated.DELETE("access-tokens/revoke/:uuid", authenticationContainer.AccessTokenController.RevokeTokenByUUID)
+		authenticated.DELETE("access-tokens/revoke/current-token", authenticationContainer.AccessTokenController.RevokeCurrentToken)
+	}
+
+	// users
+	{
+		authenticated.GET("users", middlewares.RequirePermission(permissions.UsersList),
+			middlewares.QueryParametersBuilderMiddleware(models.UserModel{}),
+			userContainer.UserController.GetList)
+		authenticated.GET("users/:uuid", middlewares.RequirePermission(permissions.UsersShow),
+			userContainer.UserController.GetByUuid)
+		authenticated.POST("users", middlewares.RequirePermission(permissions.UsersCreate),
+			adminContainer.AdminUserController.Create)
+		authenticated.PATCH("users/:uuid", middlewares.RequirePermission(permissions.UsersUpdate),
+			adminContainer.AdminUserController.Update)
+		authenticated.POST("users/:uuid/deactivate", middlewares.RequirePermission(permissions.UsersDeactivate),
+			adminContainer.AdminUserController.Deactivate)
+		authenticated.POST("users/:uuid/reactivate", middlewares.RequirePermission(permissions.UsersDeactivate),
+			adminContainer.AdminUserController.Reactivate)
+		authenticated.DELETE("users/:uuid", middlewares.RequirePermission(permissions.UsersDelete),
+			adminContainer.AdminUserController.Delete)
+		authenticated.POST("users/:uuid/restore", middlewares.RequirePermission(permissions.UsersRestore),
+			adminContainer.AdminUserController.Restore)
+		authenticated.DELETE("users/:uuid/force", middlewares.RequirePermission(permissions.UsersForceDelete), recentAuth,
+			adminContainer.AdminUserController.ForceDelete)
+		authenticated.POST("users/:uuid/impersonate", middlewares.RequirePermission(permissions.UsersImpersonate), recentAuth,
+			adminContainer.AdminUserController.Impersonate)
+	}
+
+	// sessions of users
+	{
+		authenticated.GET("users/:uuid/sessions", middlewares.RequirePermission(permissions.SessionsList),
+			middlewares.QueryParametersBuilderMiddleware(models.AccessTokenModel{}),
+			adminContainer.AdminUserController.GetSessions)
+		authenticated.DELETE("users/:uuid/sessions", middlewares.RequirePermission(permissions.SessionsRevoke),
+			adminContainer.AdminUserController.RevokeSessions)
+		authenticated.DELETE("users/:uuid/sessions/:session", middlewares.RequirePermission(permissions.SessionsRevoke),
+			adminContainer.AdminUserController.RevokeSession)
+	}
+
+	// audit log
+	{
+		authenticated.GET("audit-events", middlewares.RequirePermission(permissions.AuditEventsList),
+			middlewares.QueryParametersBuilderMiddleware(models.AuditEventModel{}),
+			adminContainer.AdminAuditController.GetList)
+	}
+
+	// roles and permissions
+	{
+		authenticated.GET("roles", middlewares.RequirePermission(permissions.RolesList),
+			middlewares.QueryParametersBuilderMiddleware(models.RoleModel{}),
+			authorizationContainer.AuthorizationController.GetRoles)
+		authenticated.GET("permissions", middlewares.RequirePermission(permissions.PermissionsList),
+			middlewares.QueryParametersBuilderMiddleware(models.PermissionModel{}),
+			authorizationContainer.AuthorizationController.GetPermissions)
+		authenticated.GET("users/:uuid/roles", middlewares.RequirePermission(permissions.RolesList),
+			authorizationContainer.AuthorizationController.GetUserRoles)
+		authenticated.POST("users/:uuid/roles", middlewares.RequirePermission(permissions.RolesAssign),
+			authorizationContainer.AuthorizationController.AssignUserRole)
+		authenticated.DELETE("users/:uuid/roles/:role", middlewares.RequirePermission(permissions.RolesAssign),
+			authorizationContainer.AuthorizationController.RevokeUserRole)
+	}
+}
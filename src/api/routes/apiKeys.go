@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/http/middlewares"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/providers"
+)
+
+func RegisterApiKeysRouter(router *gin.RouterGroup) {
+	authenticationContainer := providers.GetAuthenticationContainer()
+	apiKeys := router.Group("api-keys").
+		Use(authenticationContainer.AuthenticationMiddleware.Middleware("user"))
+	{
+		apiKeys.GET("", middlewares.QueryParametersBuilderMiddleware(models.ApiKeyModel{}),
+			authenticationContainer.ApiKeyController.GetList)
+		apiKeys.POST("", middlewares.DenyImpersonation, authenticationContainer.ApiKeyController.Create)
+		apiKeys.DELETE(":uuid", middlewares.DenyImpersonation, authenticationContainer.ApiKeyController.Revoke)
+	}
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/http/middlewares"
 	"go-auth-otp-service/src/providers"
 	"go-auth-otp-service/src/services"
 )
@@ -17,14 +18,55 @@ func AuthenticationRouter(router *gin.RouterGroup) {
 		providers.ProvideRateLimiterService(),
 	).SetLimiter(services.CriticalLimiter()).SetKey(services.GenericCriticalKeyGetter("verify-otp"))
 
+	rateLimiterTrustedDevice := providers.ProvideRateLimiterMiddleware(
+		providers.ProvideRateLimiterService(),
+	).SetLimiter(services.CriticalLimiter()).SetKey(services.GenericCriticalKeyGetter("trusted-device"))
+
+	rateLimiterNotMe := providers.ProvideRateLimiterMiddleware(
+		providers.ProvideRateLimiterService(),
+	).SetLimiter(services.CriticalLimiter()).SetKey(services.GenericCriticalKeyGetter("not-me"))
+
+	rateLimiterStepUp := providers.ProvideRateLimiterMiddleware(
+		providers.ProvideRateLimiterService(),
+	).SetLimiter(services.CriticalLimiter()).SetKey(services.GenericCriticalKeyGetter("step-up"))
+
+	rateLimiterClientToken := providers.ProvideRateLimiterMiddleware(
+		providers.ProvideRateLimiterService(),
+	).SetKey(services.GenericCriticalKeyGetter("client-token"))
+
 	// define route
 	authentication := router.Group("authentication")
 
 	// register
 	register := authentication.Group("register")
 	{
-		register.POST("send-otp", rateLimiterSendOtp.Middleware, registerController.UserRegisterController.SendOtp)
-		register.POST("verify-otp", rateLimiterVerifyOtp.Middleware, registerController.UserRegisterController.VerifyOtp)
+		register.POST("send-otp", rateLimiterSendOtp.Middleware, middlewares.GeoBlock, registerController.UserRegisterController.SendOtp)
+		register.POST("verify-otp", rateLimiterVerifyOtp.Middleware, middlewares.GeoBlock, registerController.AuthenticationMiddleware.DPoP,
+			registerController.UserRegisterController.VerifyOtp)
 	}
 
+	// otp-less login of remembered devices
+	authentication.POST("trusted-device", rateLimiterTrustedDevice.Middleware, middlewares.GeoBlock,
+		registerController.AuthenticationMiddleware.DPoP, registerController.TrustedDeviceController.Login)
+
+	// re-authentication of the current session before sensitive operations
+	stepUp := authentication.Group("step-up").
+		Use(registerController.AuthenticationMiddleware.Middleware("user"), middlewares.DenyImpersonation, rateLimiterStepUp.Middleware)
+	{
+		stepUp.POST("challenge", registerController.StepUpController.Challenge)
+		stepUp.POST("verify", registerController.StepUpController.Verify)
+	}
+
+	// revocation from new device notifications, the link opens a confirmation page whose form revokes the session
+	authentication.GET("sessions/:id/not-me", rateLimiterNotMe.Middleware, registerController.DeviceController.ConfirmNotMe)
+	authentication.POST("sessions/:id/not-me", rateLimiterNotMe.Middleware, registerController.DeviceController.NotMe)
+
+	// service-to-service tokens
+	authentication.POST("token", rateLimiterClientToken.Middleware, registerController.AuthenticationMiddleware.DPoP,
+		registerController.ClientCredentialsController.IssueToken)
+
+	// internal services check the access tokens of users they are called with
+	authentication.POST("introspect", registerController.AuthenticationMiddleware.Middleware("client"),
+		registerController.IntrospectionController.Introspect)
+
 }
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/http/middlewares"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/permissions"
+	"go-auth-otp-service/src/providers"
+)
+
+func AuthorizationRouter(router *gin.RouterGroup) {
+	authorizationContainer := providers.GetAuthorizationContainer()
+	authenticationContainer := providers.GetAuthenticationContainer()
+
+	// roles
+	router.GET("roles", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+		middlewares.RequirePermission(permissions.RolesList),
+		middlewares.QueryParametersBuilderMiddleware(models.RoleModel{}),
+		authorizationContainer.AuthorizationController.GetRoles)
+
+	// permissions
+	router.GET("permissions", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+		middlewares.RequirePermission(permissions.PermissionsList),
+		middlewares.QueryParametersBuilderMiddleware(models.PermissionModel{}),
+		authorizationContainer.AuthorizationController.GetPermissions)
+
+	// user roles
+	userRoles := router.Group("users/:uuid/roles").
+		Use(authenticationContainer.AuthenticationMiddleware.Middleware("user"))
+	{
+		userRoles.GET("", middlewares.RequirePermission(permissions.RolesList),
+			authorizationContainer.AuthorizationController.GetUserRoles)
+		userRoles.POST("", middlewares.RequirePermission(permissions.RolesAssign),
+			authorizationContainer.AuthorizationController.AssignUserRole)
+		userRoles.DELETE(":role", middlewares.RequirePermission(permissions.RolesAssign),
+			authorizationContainer.AuthorizationController.RevokeUserRole)
+	}
+}
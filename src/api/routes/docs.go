@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/providers"
+)
+
+func DocsRouter(router *gin.RouterGroup) {
+	docsContainer := providers.GetDocsContainer()
+
+	// the OpenAPI document and Swagger UI
+	router.GET("openapi.json", docsContainer.OpenAPIController.Spec)
+	router.GET("docs", docsContainer.OpenAPIController.SwaggerUI)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/providers"
+)
+
+// HealthRouter registers the probes on the root of the router, they are not part of the versioned api.
+func HealthRouter(router *gin.Engine) {
+	healthContainer := providers.GetHealthContainer()
+
+	// liveness and readiness probes
+	router.GET("healthz", healthContainer.HealthController.Live)
+	router.GET("readyz", healthContainer.HealthController.Ready)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// V1Router registers every route of the v1 api, the OpenAPI document is generated from them.
+func V1Router(v1 *gin.RouterGroup) {
+	AuthenticationRouter(v1)
+	UserRouter(v1)
+	RegisterAccessTokensRouter(v1)
+	RegisterApiKeysRouter(v1)
+	AuthorizationRouter(v1)
+	AdminRouter(v1)
+	StorageRouter(v1)
+	DocsRouter(v1)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/providers"
+)
+
+func StorageRouter(router *gin.RouterGroup) {
+	storageContainer := providers.GetStorageContainer()
+
+	// define route
+	storage := router.Group("storage")
+
+	// files of the local storage driver, guarded by their signed urls
+	{
+		storage.GET("*path", storageContainer.StorageController.Serve)
+	}
+}
@@ -4,23 +4,58 @@ import (
 	"github.com/gin-gonic/gin"
 	"go-auth-otp-service/src/api/http/middlewares"
 	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/permissions"
 	"go-auth-otp-service/src/providers"
+	"time"
 )
 
 func UserRouter(router *gin.RouterGroup) {
 	userContainer := providers.GetUserContainer()
 	authenticationContainer := providers.GetAuthenticationContainer()
 
+	// sensitive operations need the session to be authenticated again through the step-up endpoints
+	recentAuth := middlewares.RequireRecentAuth(10 * time.Minute)
+
 	// define route
 	users := router.Group("users")
 
+	// authenticated user's profile
+	{
+		users.GET("me", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+			userContainer.UserController.Me)
+		users.PATCH("me", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+			userContainer.UserController.UpdateMe)
+		users.DELETE("me", authenticationContainer.AuthenticationMiddleware.Middleware("user"), recentAuth,
+			userContainer.AccountController.Delete)
+		users.GET("me/export", authenticationContainer.AuthenticationMiddleware.Middleware("user"), recentAuth,
+			userContainer.AccountController.Export)
+		users.GET("me/trusted-devices", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+			middlewares.QueryParametersBuilderMiddleware(models.TrustedDeviceModel{}),
+			authenticationContainer.TrustedDeviceController.GetList)
+		users.DELETE("me/trusted-devices", authenticationContainer.AuthenticationMiddleware.Middleware("user"), recentAuth,
+			authenticationContainer.TrustedDeviceController.RevokeAll)
+		users.DELETE("me/trusted-devices/:uuid", authenticationContainer.AuthenticationMiddleware.Middleware("user"), middlewares.DenyImpersonation,
+			authenticationContainer.TrustedDeviceController.Revoke)
+		users.GET("me/audit-events", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+			middlewares.QueryParametersBuilderMiddleware(models.AuditEventModel{}),
+			userContainer.AuditController.GetMine)
+		users.GET("me/profile-image", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+			userContainer.ProfileImageController.Show)
+		users.POST("me/profile-image", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+			userContainer.ProfileImageController.Upload)
+		users.DELETE("me/profile-image", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+			userContainer.ProfileImageController.Delete)
+	}
+
 	// user
 	{
 		users.GET("", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+			middlewares.RequirePermission(permissions.UsersList),
 			middlewares.QueryParametersBuilderMiddleware(models.UserModel{}),
 			userContainer.UserController.GetList)
 
 		users.GET(":uuid", authenticationContainer.AuthenticationMiddleware.Middleware("user"),
+			middlewares.RequirePermission(permissions.UsersShow),
 			userContainer.UserController.GetByUuid)
 	}
 
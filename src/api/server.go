@@ -4,24 +4,49 @@ import (
 	"fmt"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/api/grpc/interceptors"
+	authv1 "go-auth-otp-service/src/api/grpc/proto/auth/v1"
+	"go-auth-otp-service/src/api/grpc/servers"
 	"go-auth-otp-service/src/api/http/middlewares"
 	"go-auth-otp-service/src/api/routes"
 	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/providers"
+	"go-auth-otp-service/src/services"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"log"
+	"net"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
 var (
 	configs = config.GetInstance()
 	g       errgroup.Group
+	// grpcServer and grpcHealth are nil until the gRPC server starts.
+	grpcServer atomic.Pointer[grpc.Server]
+	grpcHealth atomic.Pointer[health.Server]
 )
 
+// grpcStopTimeout bounds how long the gRPC server waits for the calls in flight before closing them.
+const grpcStopTimeout = 10 * time.Second
+
 func Init() (err error) {
 	g.Go(func() error {
 		return initUserServer()
 	})
 
+	// internal services speak gRPC, its server only starts when GRPC_PORT is set
+	if configs.Get("GRPC_PORT") != "" {
+		g.Go(func() error {
+			return initGrpcServer()
+		})
+	}
+
 	if err = g.Wait(); err != nil {
 		log.Fatalln(err)
 		return err
@@ -30,6 +55,33 @@ func Init() (err error) {
 	return err
 }
 
+// Shutdown fails the readiness of the http and gRPC servers, so the instance is taken out of the load balancer,
+// keeps serving for the drain and then stops the gRPC server once its calls in flight are answered.
+func Shutdown(drain time.Duration) {
+	services.MarkShuttingDown()
+	if health := grpcHealth.Load(); health != nil {
+		health.Shutdown()
+	}
+
+	if drain > 0 {
+		log.Printf("Application draining for %s.\n", drain)
+		time.Sleep(drain)
+	}
+
+	if server := grpcServer.Load(); server != nil {
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(grpcStopTimeout):
+			server.Stop()
+		}
+	}
+}
+
 func getNewRouter() *gin.Engine {
 	// set gin to release mode.
 	gin.SetMode(gin.ReleaseMode)
@@ -38,13 +90,15 @@ func getNewRouter() *gin.Engine {
 	router := gin.New()
 
 	// Attach CORS middleware.
-
+	// Credentials, like the session cookies, are only shared with the origins listed in CORS_ALLOWED_ORIGINS.
+	allowedOrigins := allowedOrigins()
 	router.Use(cors.New(cors.Config{
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "Accept", "Accept-Language", "User-Agent", "Cache-Control", "Set-Cookie"},
-		AllowCredentials: true,
-		AllowAllOrigins:  true,
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "Accept", "Accept-Language", "User-Agent", "Cache-Control", "Set-Cookie", "X-Request-ID", "X-Device-Name", "X-Device-Platform", "X-App-Version", middlewares.CSRFHeader, "DPoP"},
+		AllowCredentials: len(allowedOrigins) > 0,
+		AllowAllOrigins:  len(allowedOrigins) == 0,
+		AllowOrigins:     allowedOrigins,
+		ExposeHeaders:    []string{"Content-Length", "WWW-Authenticate"},
 		MaxAge:           12 * time.Hour,
 	}))
 
@@ -57,18 +111,28 @@ func getNewRouter() *gin.Engine {
 	// Attach i18n middleware.
 	router.Use(middlewares.I18n)
 
+	// Attach request context middleware.
+	router.Use(middlewares.RequestContext)
+
 	return router
 }
 
+// allowedOrigins reads the comma separated CORS_ALLOWED_ORIGINS.
+func allowedOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(configs.Get("CORS_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
 func initUserServer() error {
 	router := getNewRouter()
 
-	v1 := router.Group("api/v1")
-	{
-		routes.AuthenticationRouter(v1)
-		routes.UserRouter(v1)
-		routes.RegisterAccessTokensRouter(v1)
-	}
+	routes.HealthRouter(router)
+	routes.V1Router(router.Group("api/v1"))
 
 	// Run App.
 	if err := router.Run(
@@ -79,3 +143,31 @@ func initUserServer() error {
 
 	return nil
 }
+
+func initGrpcServer() error {
+	grpcContainer := providers.GetGrpcContainer()
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptors.RequestContext,
+		interceptors.Logger,
+		interceptors.GeoBlock(servers.GeoBlockedMethods...),
+		interceptors.RateLimiter(servers.AuthLimits(services.CriticalLimiter())),
+		grpcContainer.AuthenticationInterceptor.Interceptor(servers.AuthRules),
+	))
+
+	authv1.RegisterAuthServiceServer(server, grpcContainer.AuthServer)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	healthServer.SetServingStatus(servers.AuthServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", configs.Get("GRPC_PORT")))
+	if err != nil {
+		return err
+	}
+	grpcServer.Store(server)
+	grpcHealth.Store(healthServer)
+
+	// Run gRPC server.
+	return server.Serve(listener)
+}
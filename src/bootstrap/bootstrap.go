@@ -4,18 +4,25 @@ import (
 	"context"
 	"go-auth-otp-service/src/api"
 	"go-auth-otp-service/src/cache"
+	"go-auth-otp-service/src/config"
 	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/geoip"
+	"go-auth-otp-service/src/jobs"
+	"go-auth-otp-service/src/notifications"
 	"go-auth-otp-service/src/pkg/i18n"
+	"go-auth-otp-service/src/providers"
+	"go-auth-otp-service/src/storage"
 	"go.uber.org/zap"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 )
 
 func Init() (err error) {
-	_, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	sc := make(chan os.Signal, 1)
@@ -41,6 +48,28 @@ func Init() (err error) {
 		log.Fatal("Failed to Initialize", zap.String("Service", "Cache"), zap.Error(err), zap.Time("timestamp", time.Now()))
 	}
 
+	// Initialize Storage
+	err = storage.Init()
+	if err != nil {
+		log.Fatal("Failed to Initialize", zap.String("Service", "Storage"), zap.Error(err), zap.Time("timestamp", time.Now()))
+	}
+
+	// Initialize GeoIP
+	err = geoip.Init()
+	if err != nil {
+		log.Fatal("Failed to Initialize", zap.String("Service", "GeoIP"), zap.Error(err), zap.Time("timestamp", time.Now()))
+	}
+
+	// Initialize Notifications
+	err = notifications.Init()
+	if err != nil {
+		log.Fatal("Failed to Initialize", zap.String("Service", "Notifications"), zap.Error(err), zap.Time("timestamp", time.Now()))
+	}
+
+	// Start background jobs
+	jobsContainer := providers.GetJobsContainer()
+	jobs.Start(ctx, jobsContainer.AnonymizeUsersJob, jobsContainer.PruneTokensJob)
+
 	//Initialize api
 	go func() {
 		err = api.Init()
@@ -59,6 +88,10 @@ func Init() (err error) {
 	// Shutting down application
 	log.Printf("Application shutting down....   \n")
 
+	// Fail the readiness and keep serving for SHUTDOWN_DRAIN_SECONDS, until the load balancer stops sending requests.
+	drain, _ := strconv.Atoi(config.GetInstance().Get("SHUTDOWN_DRAIN_SECONDS"))
+	api.Shutdown(time.Duration(drain) * time.Second)
+
 	// Close Database
 	err = database.GetInstance().Close()
 	if err != nil {
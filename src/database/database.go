@@ -32,6 +32,11 @@ type Database struct {
 	driver IDatabaseDriver // The database driver, implementing IDatabaseDriver for database operations.
 }
 
+// New wraps a driver that is connected already, e.g. a dry run client in tests.
+func New(driver IDatabaseDriver) *Database {
+	return &Database{driver: driver}
+}
+
 // Init initializes the database by establishing a connection.
 // It retrieves the singleton instance of the Database and calls Connect on it.
 func Init() (err error) {
@@ -0,0 +1,4 @@
+alter table access_tokens
+    drop column if exists scopes;
+
+DROP TABLE IF EXISTS clients;
\ No newline at end of file
@@ -0,0 +1,25 @@
+create table if not exists clients
+(
+    id            bigserial    primary key,
+    uuid          uuid         not null,
+    name          varchar(255) not null,
+    client_id     varchar(100) not null,
+    client_secret text         not null,
+    scopes        text[]       not null default '{}',
+    is_active     boolean      default true,
+    created_at    timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at    timestamp with time zone DEFAULT NULL
+);
+
+create unique index if not exists idx_clients_uuid
+    on clients (uuid);
+
+create unique index if not exists idx_clients_client_id
+    on clients (client_id);
+
+create index if not exists idx_clients_deleted_at
+    on clients (deleted_at);
+
+alter table access_tokens
+    add column if not exists scopes text[] default '{}';
@@ -0,0 +1,28 @@
+create table if not exists api_keys
+(
+    id           bigserial    primary key,
+    uuid         uuid         not null,
+    owner_id     bigint       not null,
+    owner_type   text         not null,
+    name         varchar(255) not null,
+    key_id       varchar(100) not null,
+    key          text         not null,
+    scopes       text[]       default '{}',
+    expires_at   timestamp with time zone DEFAULT NULL,
+    last_used_at timestamp with time zone DEFAULT NULL,
+    created_at   timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at   timestamp with time zone DEFAULT NULL
+);
+
+create unique index if not exists idx_api_keys_uuid
+    on api_keys (uuid);
+
+create unique index if not exists idx_api_keys_key_id
+    on api_keys (key_id);
+
+create index if not exists idx_api_keys_owner
+    on api_keys (owner_id, owner_type);
+
+create index if not exists idx_api_keys_deleted_at
+    on api_keys (deleted_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS owner_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS permissions;
\ No newline at end of file
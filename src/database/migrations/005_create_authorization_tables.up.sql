@@ -0,0 +1,48 @@
+create table if not exists permissions
+(
+    id          bigserial    primary key,
+    uuid        uuid         not null,
+    name        varchar(100) not null,
+    title       varchar(255) default NULL::character varying,
+    created_at  timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+create unique index if not exists idx_permissions_uuid
+    on permissions (uuid);
+
+create unique index if not exists idx_permissions_name
+    on permissions (name);
+
+create table if not exists roles
+(
+    id          bigserial    primary key,
+    uuid        uuid         not null,
+    name        varchar(100) not null,
+    title       varchar(255) default NULL::character varying,
+    description text         default NULL,
+    created_at  timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+create unique index if not exists idx_roles_uuid
+    on roles (uuid);
+
+create unique index if not exists idx_roles_name
+    on roles (name);
+
+create table if not exists role_permissions
+(
+    role_id       bigint not null references roles (id) on delete cascade,
+    permission_id bigint not null references permissions (id) on delete cascade,
+    primary key (role_id, permission_id)
+);
+
+create table if not exists owner_roles
+(
+    owner_id   bigint not null,
+    owner_type text   not null,
+    role_id    bigint not null references roles (id) on delete cascade,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    primary key (owner_id, owner_type, role_id)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS admins;
\ No newline at end of file
@@ -0,0 +1,26 @@
+create table if not exists admins
+(
+    id           bigserial    primary key,
+    uuid         uuid         not null,
+    is_active    boolean      default true,
+    first_name   varchar(255) default NULL::character varying,
+    last_name    varchar(255) default NULL::character varying,
+    username     varchar(100) not null,
+    mobile       varchar(100) not null,
+    email        varchar(100) default NULL::character varying,
+    password     text         not null,
+    totp_secret  text         default NULL,
+    totp_enabled boolean      default false,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamp with time zone DEFAULT NULL
+);
+
+create unique index if not exists idx_admins_uuid
+    on admins (uuid);
+
+create unique index if not exists idx_admins_username
+    on admins (username);
+
+create index if not exists idx_admins_deleted_at
+    on admins (deleted_at);
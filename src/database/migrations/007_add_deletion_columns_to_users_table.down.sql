@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_deletion_requested_at;
+
+alter table users
+    drop column if exists deletion_requested_at,
+    drop column if exists anonymized_at;
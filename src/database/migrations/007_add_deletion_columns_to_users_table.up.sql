@@ -0,0 +1,7 @@
+alter table users
+    add column if not exists deletion_requested_at timestamp with time zone DEFAULT NULL,
+    add column if not exists anonymized_at         timestamp with time zone DEFAULT NULL;
+
+create index if not exists idx_users_deletion_requested_at
+    on users (deletion_requested_at)
+    where anonymized_at is null;
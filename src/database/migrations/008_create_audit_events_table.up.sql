@@ -0,0 +1,44 @@
+create table if not exists audit_events
+(
+    id           bigserial    primary key,
+    uuid         uuid         not null,
+    event        varchar(100) not null,
+    outcome      varchar(20)  not null,
+    owner_id     bigint       default NULL,
+    owner_type   varchar(20)  default NULL::character varying,
+    actor_id     bigint       default NULL,
+    actor_type   varchar(20)  default NULL::character varying,
+    ip           varchar(100) default NULL::character varying,
+    user_agent   text         default NULL,
+    request_uuid uuid         default NULL,
+    metadata     jsonb        default NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+create unique index if not exists idx_audit_events_uuid
+    on audit_events (uuid);
+
+create index if not exists idx_audit_events_owner
+    on audit_events (owner_type, owner_id, created_at);
+
+create index if not exists idx_audit_events_event
+    on audit_events (event, created_at);
+
+create index if not exists idx_audit_events_request_uuid
+    on audit_events (request_uuid);
+
+-- audit events are append-only, rows can never be changed or removed
+create or replace function audit_events_append_only() returns trigger as
+$$
+begin
+    raise exception 'audit_events is append-only';
+end;
+$$ language plpgsql;
+
+create trigger audit_events_no_update_or_delete
+    before update or delete on audit_events
+    for each row execute function audit_events_append_only();
+
+create trigger audit_events_no_truncate
+    before truncate on audit_events
+    for each statement execute function audit_events_append_only();
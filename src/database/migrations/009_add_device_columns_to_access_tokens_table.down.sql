@@ -0,0 +1,9 @@
+alter table access_tokens
+    drop column if exists device_name,
+    drop column if exists device_type,
+    drop column if exists platform,
+    drop column if exists os,
+    drop column if exists os_version,
+    drop column if exists browser,
+    drop column if exists browser_version,
+    drop column if exists app_version;
@@ -0,0 +1,9 @@
+alter table access_tokens
+    add column if not exists device_name     varchar(100) DEFAULT NULL,
+    add column if not exists device_type     varchar(20)  DEFAULT NULL,
+    add column if not exists platform        varchar(50)  DEFAULT NULL,
+    add column if not exists os              varchar(50)  DEFAULT NULL,
+    add column if not exists os_version      varchar(50)  DEFAULT NULL,
+    add column if not exists browser         varchar(50)  DEFAULT NULL,
+    add column if not exists browser_version varchar(50)  DEFAULT NULL,
+    add column if not exists app_version     varchar(50)  DEFAULT NULL;
@@ -0,0 +1,9 @@
+alter table audit_events
+    drop column if exists country_code,
+    drop column if exists country,
+    drop column if exists city;
+
+alter table access_tokens
+    drop column if exists country_code,
+    drop column if exists country,
+    drop column if exists city;
@@ -0,0 +1,9 @@
+alter table access_tokens
+    add column if not exists country_code varchar(2)   DEFAULT NULL,
+    add column if not exists country      varchar(100) DEFAULT NULL,
+    add column if not exists city         varchar(100) DEFAULT NULL;
+
+alter table audit_events
+    add column if not exists country_code varchar(2)   DEFAULT NULL,
+    add column if not exists country      varchar(100) DEFAULT NULL,
+    add column if not exists city         varchar(100) DEFAULT NULL;
@@ -0,0 +1,29 @@
+create table if not exists trusted_devices
+(
+    id           bigserial    primary key,
+    uuid         uuid         not null,
+    user_id      bigint       not null references users (id) on delete cascade,
+    device_id    varchar(255) not null,
+    name         varchar(100) DEFAULT NULL,
+    token_id     varchar(100) not null,
+    token        text         not null,
+    ip           varchar(100) DEFAULT NULL,
+    user_agent   text         DEFAULT NULL,
+    expires_at   timestamp with time zone NOT NULL,
+    last_used_at timestamp with time zone DEFAULT NULL,
+    created_at   timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at   timestamp with time zone DEFAULT NULL
+);
+
+create unique index if not exists idx_trusted_devices_uuid
+    on trusted_devices (uuid);
+
+create unique index if not exists idx_trusted_devices_token_id
+    on trusted_devices (token_id);
+
+create index if not exists idx_trusted_devices_user_id
+    on trusted_devices (user_id);
+
+create index if not exists idx_trusted_devices_deleted_at
+    on trusted_devices (deleted_at);
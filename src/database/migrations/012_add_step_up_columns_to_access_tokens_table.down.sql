@@ -0,0 +1,3 @@
+alter table access_tokens
+    drop column if exists auth_time,
+    drop column if exists acr;
@@ -0,0 +1,3 @@
+alter table access_tokens
+    add column if not exists auth_time timestamp with time zone DEFAULT NULL,
+    add column if not exists acr       varchar(20)              DEFAULT NULL;
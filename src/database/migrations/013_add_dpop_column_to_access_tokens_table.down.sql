@@ -0,0 +1,2 @@
+alter table access_tokens
+    drop column if exists dpop_jkt;
@@ -0,0 +1,2 @@
+alter table access_tokens
+    add column if not exists dpop_jkt varchar(64) DEFAULT NULL;
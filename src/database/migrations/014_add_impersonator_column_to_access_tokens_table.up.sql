@@ -0,0 +1,2 @@
+alter table access_tokens
+    add column if not exists impersonator_id bigint DEFAULT NULL references admins (id) on delete set null;
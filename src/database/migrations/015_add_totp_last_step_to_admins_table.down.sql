@@ -0,0 +1,2 @@
+alter table admins
+    drop column if exists totp_last_step;
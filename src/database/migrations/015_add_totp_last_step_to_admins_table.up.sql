@@ -0,0 +1,2 @@
+alter table admins
+    add column if not exists totp_last_step bigint not null DEFAULT 0;
@@ -0,0 +1,6 @@
+create or replace function audit_events_append_only() returns trigger as
+$$
+begin
+    raise exception 'audit_events is append-only';
+end;
+$$ language plpgsql;
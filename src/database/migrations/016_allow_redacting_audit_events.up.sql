@@ -0,0 +1,25 @@
+-- audit events stay append-only, except that the anonymisation of a deleted user may clear the personal columns
+-- of its events from a transaction which set audit_events.redact, every other column must stay unchanged
+create or replace function audit_events_append_only() returns trigger as
+$$
+begin
+    if TG_OP = 'UPDATE' and current_setting('audit_events.redact', true) = 'on' then
+        if NEW.ip is null
+            and NEW.user_agent is null
+            and NEW.country_code is null
+            and NEW.country is null
+            and NEW.city is null
+            and NEW.metadata is null
+            and (NEW.id, NEW.uuid, NEW.event, NEW.outcome, NEW.owner_id, NEW.owner_type,
+                 NEW.actor_id, NEW.actor_type, NEW.request_uuid, NEW.created_at)
+                is not distinct from
+                (OLD.id, OLD.uuid, OLD.event, OLD.outcome, OLD.owner_id, OLD.owner_type,
+                 OLD.actor_id, OLD.actor_type, OLD.request_uuid, OLD.created_at)
+        then
+            return NEW;
+        end if;
+    end if;
+
+    raise exception 'audit_events is append-only';
+end;
+$$ language plpgsql;
@@ -0,0 +1,2 @@
+-- the empty values are not restored, null is what the users were created with
+select 1;
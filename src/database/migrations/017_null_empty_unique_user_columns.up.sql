@@ -0,0 +1,4 @@
+-- users saved without an email or a national identity code were written with '' instead of null,
+-- which the unique indexes of those columns let only one of them hold
+update users set email = null where email = '';
+update users set national_identity_code = null where national_identity_code = '';
@@ -0,0 +1,4 @@
+drop index if exists idx_trusted_devices_access_token_id;
+
+alter table trusted_devices
+    drop column if exists access_token_id;
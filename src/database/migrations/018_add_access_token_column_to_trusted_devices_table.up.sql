@@ -0,0 +1,5 @@
+alter table trusted_devices
+    add column if not exists access_token_id bigint DEFAULT NULL references access_tokens (id) on delete set null;
+
+create index if not exists idx_trusted_devices_access_token_id
+    on trusted_devices (access_token_id);
@@ -0,0 +1,21 @@
+-- the devices every owner signed in from, kept apart from access_tokens so pruning the tokens does not forget them
+create table if not exists known_devices
+(
+    id            bigserial    primary key,
+    owner_id      bigint       not null,
+    owner_type    varchar(20)  not null,
+    ip            varchar(255) not null,
+    user_agent    varchar(255) not null,
+    first_seen_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+create unique index if not exists idx_known_devices_owner_device
+    on known_devices (owner_id, owner_type, ip, user_agent);
+
+-- the tokens not pruned yet hold the history so far
+insert into known_devices (owner_id, owner_type, ip, user_agent, first_seen_at)
+select owner_id, owner_type, ip, user_agent, coalesce(min(created_at), now())
+from access_tokens
+where owner_id is not null and owner_type is not null
+group by owner_id, owner_type, ip, user_agent
+on conflict do nothing;
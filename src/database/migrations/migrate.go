@@ -2,55 +2,87 @@ package migrations
 
 import (
 	"embed"
+	"errors"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"go-auth-otp-service/src/config"
 	"go-auth-otp-service/src/database"
+	"io/fs"
 	"log"
+	"sync"
 )
 
 //go:embed *.sql
 var migrationFS embed.FS
 
 var (
-	migration *migrate.Migrate
-	db        = database.GetInstance()
+	migration     *migrate.Migrate
+	migrationOnce sync.Once
+	db            = database.GetInstance()
 )
 
-func init() {
-	config.Init()
-	database.Init()
+// getMigration connects to the database on first use, so reading the embedded migrations does not need one.
+func getMigration() *migrate.Migrate {
+	migrationOnce.Do(func() {
+		config.Init()
+		database.Init()
 
-	driver, err := postgres.WithInstance(db.GetDB(), &postgres.Config{})
+		driver, err := postgres.WithInstance(db.GetDB(), &postgres.Config{})
 
-	if err != nil {
-		log.Fatalln(err)
-	}
+		if err != nil {
+			log.Fatalln(err)
+		}
 
-	source, err := iofs.New(migrationFS, ".")
-	if err != nil {
-		log.Fatalf("Migration service error:%v", err)
-	}
+		source, err := iofs.New(migrationFS, ".")
+		if err != nil {
+			log.Fatalf("Migration service error:%v", err)
+		}
 
-	m, err := migrate.NewWithInstance(
-		"iofs",
-		source,
-		"postgres",
-		driver,
-	)
+		m, err := migrate.NewWithInstance(
+			"iofs",
+			source,
+			"postgres",
+			driver,
+		)
 
-	if err != nil {
-		log.Fatalf("Migration service error:%v", err)
-	}
+		if err != nil {
+			log.Fatalf("Migration service error:%v", err)
+		}
 
-	migration = m
+		migration = m
+	})
+	return migration
 }
 
 func Up() error {
-	return migration.Up()
+	return getMigration().Up()
 }
 
 func Down() error {
-	return migration.Down()
+	return getMigration().Down()
+}
+
+// Latest returns the version of the last embedded migration, the version a migrated database is at.
+func Latest() (uint, error) {
+	source, err := iofs.New(migrationFS, ".")
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
 }
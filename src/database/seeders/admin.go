@@ -0,0 +1,69 @@
+package seeders
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/hash"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/permissions"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+)
+
+// SeedSuperAdmin creates the super admin account from the SUPER_ADMIN_* configurations
+// and grants it the super-admin role. It must run after SeedAuthorization.
+func SeedSuperAdmin() error {
+	configs := config.GetInstance()
+	username := configs.Get("SUPER_ADMIN_USERNAME")
+	password := configs.Get("SUPER_ADMIN_PASSWORD")
+	if username == "" || password == "" {
+		log.Println("Seeders: SUPER_ADMIN_USERNAME or SUPER_ADMIN_PASSWORD is not set, super admin is not seeded.")
+		return nil
+	}
+
+	return database.GetInstance().GetClient().Transaction(func(tx *gorm.DB) error {
+		// create the admin if it doesn't exist
+		admin := &models.AdminModel{}
+		err := tx.First(admin, "username = ?", username).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			hashedPassword, err := hash.GetInstance().Generate([]byte(password))
+			if err != nil {
+				return err
+			}
+
+			admin = &models.AdminModel{
+				Uuid:     uuid.New(),
+				IsActive: true,
+				Username: username,
+				Mobile:   configs.Get("SUPER_ADMIN_MOBILE"),
+				Password: hashedPassword,
+			}
+			if err = tx.Create(admin).Error; err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+
+		// grant the super-admin role
+		role := &models.RoleModel{}
+		if err = tx.First(role, "name = ?", permissions.SuperAdminRole).Error; err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.OwnerRoleModel{
+			OwnerID:   admin.ID,
+			OwnerType: "admin",
+			RoleID:    role.ID,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Seeders: super admin %s seeded.\n", username)
+		return nil
+	})
+}
@@ -0,0 +1,47 @@
+package seeders
+
+import (
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/permissions"
+	"gorm.io/gorm"
+	"log"
+)
+
+// SeedAuthorization creates every known permission and the super-admin role holding all of them.
+// It is safe to run repeatedly, new permissions are picked up by the super-admin role on each run.
+func SeedAuthorization() error {
+	return database.GetInstance().GetClient().Transaction(func(tx *gorm.DB) error {
+		// create permissions
+		var all []*models.PermissionModel
+		for name, title := range permissions.All() {
+			permission := &models.PermissionModel{}
+			err := tx.Where(models.PermissionModel{Name: name}).
+				Assign(models.PermissionModel{Title: title}).
+				Attrs(models.PermissionModel{Uuid: uuid.New()}).
+				FirstOrCreate(permission).Error
+			if err != nil {
+				return err
+			}
+			all = append(all, permission)
+		}
+		log.Printf("Seeders: %d permissions seeded.\n", len(all))
+
+		// create the super-admin role with every permission
+		role := &models.RoleModel{}
+		err := tx.Where(models.RoleModel{Name: permissions.SuperAdminRole}).
+			Attrs(models.RoleModel{Uuid: uuid.New(), Title: "Super Admin", Description: "Holds every permission."}).
+			FirstOrCreate(role).Error
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Model(role).Association("Permissions").Replace(all); err != nil {
+			return err
+		}
+		log.Printf("Seeders: %s role seeded.\n", permissions.SuperAdminRole)
+
+		return nil
+	})
+}
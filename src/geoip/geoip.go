@@ -0,0 +1,113 @@
+package geoip
+
+import (
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/pkg/mmdb"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	connectOnce     sync.Once // Ensures the database is loaded only once.
+	getInstanceOnce sync.Once // Ensures a single instance of GeoIP is created.
+	instance        *GeoIP    // Holds the singleton instance of GeoIP.
+)
+
+// Location is the approximate place of an ip address, unknown parts are left empty.
+type Location struct {
+	CountryCode string
+	Country     string
+	City        string
+}
+
+// String describes the location for humans, like "Berlin, Germany".
+func (location Location) String() string {
+	switch {
+	case location.City != "" && location.Country != "":
+		return location.City + ", " + location.Country
+	default:
+		return location.Country
+	}
+}
+
+// GeoIP locates ip addresses with a local database in the MaxMind format, no network is involved.
+type GeoIP struct {
+	reader           *mmdb.Reader
+	blockedCountries []string
+}
+
+// Init initializes the geolocation by loading the configured database.
+func Init() (err error) {
+	return GetInstance().Connect()
+}
+
+// Connect loads the database under GEOIP_DATABASE_PATH, geolocation is disabled when it is not set.
+func (geoip *GeoIP) Connect() (err error) {
+	connectOnce.Do(func() {
+		configs := config.GetInstance() // Retrieve configurations
+
+		for _, code := range strings.Split(configs.Get("GEOIP_BLOCKED_COUNTRIES"), ",") {
+			if code = strings.ToUpper(strings.TrimSpace(code)); code != "" {
+				geoip.blockedCountries = append(geoip.blockedCountries, code)
+			}
+		}
+
+		if path := configs.Get("GEOIP_DATABASE_PATH"); path != "" {
+			geoip.reader, err = mmdb.Open(path)
+		}
+	})
+
+	return
+}
+
+// Lookup returns the location of the ip, or an empty location when it is unknown.
+func (geoip *GeoIP) Lookup(ip string) Location {
+	address := net.ParseIP(ip)
+	if geoip.reader == nil || address == nil {
+		return Location{}
+	}
+
+	record, err := geoip.reader.Lookup(address)
+	if err != nil || record == nil {
+		return Location{}
+	}
+
+	country := field(record, "country")
+	return Location{
+		CountryCode: text(country, "iso_code"),
+		Country:     text(field(country, "names"), "en"),
+		City:        text(field(field(record, "city"), "names"), "en"),
+	}
+}
+
+// IsBlocked reports whether the ip is located in one of the GEOIP_BLOCKED_COUNTRIES.
+func (geoip *GeoIP) IsBlocked(ip string) bool {
+	if len(geoip.blockedCountries) == 0 {
+		return false
+	}
+
+	code := geoip.Lookup(ip).CountryCode
+	return code != "" && slices.Contains(geoip.blockedCountries, code)
+}
+
+// GetInstance returns the singleton instance of the GeoIP.
+func GetInstance() *GeoIP {
+	getInstanceOnce.Do(func() {
+		instance = &GeoIP{} // Initialize the singleton instance
+	})
+	return instance
+}
+
+// field returns the nested map stored under the key of a record.
+func field(record map[string]interface{}, key string) map[string]interface{} {
+	value, _ := record[key].(map[string]interface{})
+	return value
+}
+
+// text returns the string stored under the key of a record.
+func text(record map[string]interface{}, key string) string {
+	value, _ := record[key].(string)
+	return value
+}
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/services"
+	"go.uber.org/zap"
+	"log"
+	"strconv"
+	"time"
+)
+
+// AnonymizeUsersJob erases the personal data of users once the grace period of their deletion request is over.
+type AnonymizeUsersJob struct {
+	UserService services.IUserService
+}
+
+func (job *AnonymizeUsersJob) Name() string {
+	return "anonymize-users"
+}
+
+func (job *AnonymizeUsersJob) Interval() time.Duration {
+	return time.Hour
+}
+
+func (job *AnonymizeUsersJob) Run(context.Context) error {
+	days, err := strconv.Atoi(config.GetInstance().Get("ACCOUNT_DELETION_GRACE_PERIOD_DAYS"))
+	if err != nil {
+		days = 30
+	}
+
+	count, err := job.UserService.AnonymizeDeletedUsers(time.Duration(days) * 24 * time.Hour)
+	if count > 0 {
+		log.Println("Users Anonymized.", zap.Int("count", count), zap.Time("timestamp", time.Now()))
+	}
+	return err
+}
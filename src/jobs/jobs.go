@@ -0,0 +1,40 @@
+// Package jobs runs periodic background work alongside the api.
+package jobs
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"log"
+	"time"
+)
+
+// IJob defines a unit of work run periodically by Start.
+type IJob interface {
+	Name() string                  // Name identifies the job in the logs.
+	Interval() time.Duration       // Interval is the delay between two runs.
+	Run(ctx context.Context) error // Run does the work once.
+}
+
+// Start runs every job once and then on its interval until the context is cancelled.
+func Start(ctx context.Context, jobs ...IJob) {
+	for _, job := range jobs {
+		go run(ctx, job)
+	}
+}
+
+func run(ctx context.Context, job IJob) {
+	ticker := time.NewTicker(job.Interval())
+	defer ticker.Stop()
+
+	for {
+		if err := job.Run(ctx); err != nil {
+			log.Println("Job Failed.", zap.String("Job", job.Name()), zap.Error(err), zap.Time("timestamp", time.Now()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
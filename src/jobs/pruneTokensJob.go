@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/services/authentication"
+	"go.uber.org/zap"
+	"log"
+	"strconv"
+	"time"
+)
+
+// PruneTokensJob hard deletes the expired and revoked access tokens past their retention.
+// Zero fields are read from the ACCESS_TOKEN_PRUNE_* configurations.
+type PruneTokensJob struct {
+	AccessTokenService authentication.IAccessTokenService
+	ExpiredFor         time.Duration
+	Retention          time.Duration
+	BatchSize          int
+}
+
+func (job *PruneTokensJob) Name() string {
+	return "prune-tokens"
+}
+
+func (job *PruneTokensJob) Interval() time.Duration {
+	return time.Hour
+}
+
+func (job *PruneTokensJob) Run(context.Context) error {
+	expiredFor, retention, batchSize := job.ExpiredFor, job.Retention, job.BatchSize
+	if expiredFor <= 0 {
+		expiredFor = configuredDays("ACCESS_TOKEN_PRUNE_EXPIRED_DAYS", 7)
+	}
+	if retention <= 0 {
+		retention = configuredDays("ACCESS_TOKEN_PRUNE_REVOKED_DAYS", 30)
+	}
+	if batchSize <= 0 {
+		batchSize, _ = strconv.Atoi(config.GetInstance().Get("ACCESS_TOKEN_PRUNE_BATCH_SIZE"))
+		if batchSize <= 0 {
+			batchSize = 1000
+		}
+	}
+
+	count, err := job.AccessTokenService.PruneTokens(expiredFor, retention, batchSize)
+	if count > 0 {
+		log.Println("Access Tokens Pruned.", zap.Int("count", count), zap.Time("timestamp", time.Now()))
+	}
+	return err
+}
+
+// configuredDays reads a number of days from the configuration, falling back to the default.
+func configuredDays(key string, fallback int) time.Duration {
+	days, err := strconv.Atoi(config.GetInstance().Get(key))
+	if err != nil || days < 0 {
+		days = fallback
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 	"time"
 )
@@ -17,6 +18,22 @@ type AccessTokenModel struct {
 	RefreshTokenExpiresAt time.Time      `json:"refresh_token_expires_at" sort:"true"`
 	IP                    string         `json:"ip"`
 	UserAgent             string         `json:"user_agent"`
+	DeviceName            string         `json:"device_name" gorm:"default:null"`
+	DeviceType            string         `json:"device_type" gorm:"default:null" filter:"true"`
+	Platform              string         `json:"platform" gorm:"default:null" filter:"true"`
+	OS                    string         `json:"os" gorm:"column:os; default:null" filter:"true"`
+	OSVersion             string         `json:"os_version" gorm:"column:os_version; default:null"`
+	Browser               string         `json:"browser" gorm:"default:null" filter:"true"`
+	BrowserVersion        string         `json:"browser_version" gorm:"default:null"`
+	AppVersion            string         `json:"app_version" gorm:"default:null"`
+	CountryCode           string         `json:"country_code" gorm:"default:null" filter:"true"`
+	Country               string         `json:"country" gorm:"default:null"`
+	City                  string         `json:"city" gorm:"default:null"`
+	Scopes                pq.StringArray `json:"scopes" gorm:"type:text[]"`
+	AuthTime              *time.Time     `json:"auth_time"`
+	Acr                   string         `json:"acr" gorm:"default:null"`
+	DPoPJkt               string         `json:"dpop_jkt" gorm:"column:dpop_jkt; default:null"`
+	ImpersonatorID        *uint          `json:"impersonator_id,omitempty"`
 	LastUsedAt            *time.Time     `json:"last_used_at" sort:"true"`
 	CreatedAt             time.Time      `json:"created_at" sort:"true"`
 	UpdatedAt             time.Time      `json:"updated_at" sort:"true"`
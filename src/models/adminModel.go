@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AdminModel struct {
+	ID          uint      `json:"id,omitempty" gorm:"primarykey"`
+	Uuid        uuid.UUID `json:"uuid,omitempty" gorm:"type:uuid; uniqueIndex" filter:"true"`
+	IsActive    bool      `json:"is_active,omitempty" gorm:"type:bool; default:true" filter:"true"`
+	FirstName   string    `json:"first_name,omitempty" gorm:"type:varchar(255); default:null" filter:"true" like:"true" sort:"true"`
+	LastName    string    `json:"last_name,omitempty" gorm:"type:varchar(255); default:null" filter:"true" like:"true" sort:"true"`
+	Username    string    `json:"username,omitempty" gorm:"type:varchar(100); uniqueIndex; not null" filter:"true" like:"true" sort:"true"`
+	Mobile      string    `json:"mobile,omitempty" gorm:"type:varchar(100); not null" filter:"true" like:"true"`
+	Email       string    `json:"email,omitempty" gorm:"type:varchar(100); default:null" filter:"true" like:"true"`
+	Password    []byte    `json:"-" gorm:"type:text; not null"`
+	TotpSecret  string    `json:"-" gorm:"type:text; default:null"`
+	TotpEnabled bool      `json:"totp_enabled" gorm:"type:bool; default:false"`
+	// TotpLastStep is the time step of the last accepted totp code, the codes of it and the steps before are refused.
+	TotpLastStep int64          `json:"-" gorm:"type:bigint; default:0"`
+	CreatedAt    time.Time      `json:"created_at,omitempty" sort:"true"`
+	UpdatedAt    time.Time      `json:"updated_at,omitempty" sort:"true"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" sort:"true"`
+}
+
+func (*AdminModel) TableName() string {
+	return "admins"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ApiKeyModel struct {
+	ID         uint           `json:"id" gorm:"primarykey"`
+	Uuid       uuid.UUID      `json:"uuid" gorm:"type:uuid; uniqueIndex" filter:"true"`
+	OwnerID    uint           `json:"-"`
+	OwnerType  string         `json:"-"`
+	Name       string         `json:"name" gorm:"type:varchar(255); not null" filter:"true" like:"true" sort:"true"`
+	KeyID      string         `json:"key_id" gorm:"type:varchar(100); uniqueIndex; not null" filter:"true"`
+	Key        []byte         `json:"-" gorm:"type:text; not null"`
+	Scopes     pq.StringArray `json:"scopes" gorm:"type:text[]"`
+	ExpiresAt  *time.Time     `json:"expires_at" sort:"true"`
+	LastUsedAt *time.Time     `json:"last_used_at" sort:"true"`
+	CreatedAt  time.Time      `json:"created_at" sort:"true"`
+	UpdatedAt  time.Time      `json:"updated_at" sort:"true"`
+	DeletedAt  gorm.DeletedAt `json:"deleted_at" gorm:"index" sort:"true"`
+}
+
+func (*ApiKeyModel) TableName() string {
+	return "api_keys"
+}
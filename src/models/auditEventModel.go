@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AuditEventModel struct {
+	ID          uint            `json:"id" gorm:"primarykey"`
+	Uuid        uuid.UUID       `json:"uuid" gorm:"type:uuid; uniqueIndex" filter:"true"`
+	Event       string          `json:"event" gorm:"type:varchar(100); not null" filter:"true" like:"true" sort:"true"`
+	Outcome     string          `json:"outcome" gorm:"type:varchar(20); not null" filter:"true" sort:"true"`
+	OwnerID     *uint           `json:"owner_id,omitempty" filter:"true"`
+	OwnerType   string          `json:"owner_type,omitempty" gorm:"type:varchar(20); default:null" filter:"true"`
+	ActorID     *uint           `json:"actor_id,omitempty" filter:"true"`
+	ActorType   string          `json:"actor_type,omitempty" gorm:"type:varchar(20); default:null" filter:"true"`
+	IP          string          `json:"ip,omitempty" gorm:"type:varchar(100); default:null" filter:"true"`
+	CountryCode string          `json:"country_code,omitempty" gorm:"type:varchar(2); default:null" filter:"true"`
+	Country     string          `json:"country,omitempty" gorm:"type:varchar(100); default:null"`
+	City        string          `json:"city,omitempty" gorm:"type:varchar(100); default:null"`
+	UserAgent   string          `json:"user_agent,omitempty" gorm:"type:text; default:null"`
+	RequestUuid *uuid.UUID      `json:"request_uuid,omitempty" gorm:"type:uuid" filter:"true"`
+	Metadata    json.RawMessage `json:"metadata,omitempty" gorm:"type:jsonb; default:null"`
+	CreatedAt   time.Time       `json:"created_at" sort:"true"`
+}
+
+func (*AuditEventModel) TableName() string {
+	return "audit_events"
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ClientModel struct {
+	ID           uint           `json:"id,omitempty" gorm:"primarykey"`
+	Uuid         uuid.UUID      `json:"uuid,omitempty" gorm:"type:uuid; uniqueIndex" filter:"true"`
+	Name         string         `json:"name,omitempty" gorm:"type:varchar(255); not null" filter:"true" like:"true" sort:"true"`
+	ClientID     string         `json:"client_id,omitempty" gorm:"type:varchar(100); uniqueIndex; not null" filter:"true"`
+	ClientSecret []byte         `json:"-" gorm:"type:text; not null"`
+	Scopes       pq.StringArray `json:"scopes" gorm:"type:text[]"`
+	IsActive     bool           `json:"is_active,omitempty" gorm:"type:bool; default:true" filter:"true"`
+	CreatedAt    time.Time      `json:"created_at,omitempty" sort:"true"`
+	UpdatedAt    time.Time      `json:"updated_at,omitempty" sort:"true"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" sort:"true"`
+}
+
+func (*ClientModel) TableName() string {
+	return "clients"
+}
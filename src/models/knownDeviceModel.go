@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// KnownDeviceModel is an ip and user agent an owner signed in from, it outlives the tokens issued to it.
+type KnownDeviceModel struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	OwnerID     uint      `json:"-"`
+	OwnerType   string    `json:"-" gorm:"type:varchar(20); not null"`
+	IP          string    `json:"ip" gorm:"type:varchar(255); not null"`
+	UserAgent   string    `json:"user_agent" gorm:"type:varchar(255); not null"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+}
+
+func (*KnownDeviceModel) TableName() string {
+	return "known_devices"
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PermissionModel struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Uuid      uuid.UUID `json:"uuid" gorm:"type:uuid; uniqueIndex" filter:"true"`
+	Name      string    `json:"name" gorm:"type:varchar(100); uniqueIndex; not null" filter:"true" like:"true" sort:"true"`
+	Title     string    `json:"title" gorm:"type:varchar(255); default:null" like:"true" sort:"true"`
+	CreatedAt time.Time `json:"created_at" sort:"true"`
+	UpdatedAt time.Time `json:"updated_at" sort:"true"`
+}
+
+func (*PermissionModel) TableName() string {
+	return "permissions"
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type RoleModel struct {
+	ID          uint               `json:"id" gorm:"primarykey"`
+	Uuid        uuid.UUID          `json:"uuid" gorm:"type:uuid; uniqueIndex" filter:"true"`
+	Name        string             `json:"name" gorm:"type:varchar(100); uniqueIndex; not null" filter:"true" like:"true" sort:"true"`
+	Title       string             `json:"title" gorm:"type:varchar(255); default:null" like:"true" sort:"true"`
+	Description string             `json:"description" gorm:"type:text; default:null"`
+	Permissions []*PermissionModel `json:"permissions,omitempty" gorm:"many2many:role_permissions;joinForeignKey:RoleID;joinReferences:PermissionID"`
+	CreatedAt   time.Time          `json:"created_at" sort:"true"`
+	UpdatedAt   time.Time          `json:"updated_at" sort:"true"`
+}
+
+func (*RoleModel) TableName() string {
+	return "roles"
+}
+
+// OwnerRoleModel assigns a role to an owner, the owner may be any authenticatable model.
+type OwnerRoleModel struct {
+	OwnerID   uint      `json:"-" gorm:"primaryKey"`
+	OwnerType string    `json:"-" gorm:"primaryKey"`
+	RoleID    uint      `json:"-" gorm:"primaryKey"`
+	Role      RoleModel `json:"role" gorm:"foreignKey:RoleID"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (*OwnerRoleModel) TableName() string {
+	return "owner_roles"
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type TrustedDeviceModel struct {
+	ID     uint      `json:"id" gorm:"primarykey"`
+	Uuid   uuid.UUID `json:"uuid" gorm:"type:uuid; uniqueIndex" filter:"true"`
+	UserID uint      `json:"-"`
+	// AccessTokenID is the session that trusted the device, revoking it revokes the device too.
+	AccessTokenID *uint          `json:"-"`
+	DeviceID      string         `json:"-" gorm:"type:varchar(255); not null"`
+	Name          string         `json:"name" gorm:"type:varchar(100); default:null" filter:"true" like:"true" sort:"true"`
+	TokenID       string         `json:"-" gorm:"type:varchar(100); uniqueIndex; not null"`
+	Token         []byte         `json:"-" gorm:"type:text; not null"`
+	IP            string         `json:"ip" gorm:"default:null"`
+	UserAgent     string         `json:"user_agent" gorm:"default:null"`
+	ExpiresAt     time.Time      `json:"expires_at" sort:"true"`
+	LastUsedAt    *time.Time     `json:"last_used_at" sort:"true"`
+	CreatedAt     time.Time      `json:"created_at" sort:"true"`
+	UpdatedAt     time.Time      `json:"updated_at" sort:"true"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at" gorm:"index" sort:"true"`
+}
+
+func (*TrustedDeviceModel) TableName() string {
+	return "trusted_devices"
+}
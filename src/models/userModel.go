@@ -3,13 +3,14 @@ package models
 import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
 type UserModel struct {
 	ID                   uint           `json:"id,omitempty" gorm:"primarykey"`
 	Uuid                 uuid.UUID      `json:"uuid,omitempty" gorm:"type:uuid; uniqueIndex" filter:"true"`
-	IsActive             bool           `json:"is_active,omitempty" gorm:"type:bool; default:true" filter:"true" like:"true"`
+	IsActive             bool           `json:"is_active" gorm:"type:bool; default:true" filter:"true" like:"true"`
 	FirstName            string         `json:"first_name,omitempty" gorm:"type:varchar(255); default:null" filter:"true" like:"true" sort:"true"`
 	LastName             string         `json:"last_name,omitempty" gorm:"type:varchar(255); default:null" filter:"true" like:"true" sort:"true"`
 	FullName             string         `json:"full_name,omitempty" gorm:"-" filter:"true" sort:"true"`
@@ -19,6 +20,8 @@ type UserModel struct {
 	NationalIdentityCode string         `json:"national_identity_code,omitempty" gorm:"type:varchar(255); uniqueIndex; default:null" filter:"true" like:"true"`
 	Mobile               string         `json:"mobile,omitempty" gorm:"type:varchar(100); uniqueIndex; not null" filter:"true" like:"true"`
 	Email                string         `json:"email,omitempty" gorm:"type:varchar(100); default:null" filter:"true" like:"true"`
+	DeletionRequestedAt  *time.Time     `json:"deletion_requested_at,omitempty" sort:"true"`
+	AnonymizedAt         *time.Time     `json:"anonymized_at,omitempty" sort:"true"`
 	CreatedAt            time.Time      `json:"created_at,omitempty" sort:"true"`
 	UpdatedAt            time.Time      `json:"updated_at,omitempty" sort:"true"`
 	DeletedAt            gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" sort:"true"`
@@ -27,3 +30,14 @@ type UserModel struct {
 func (*UserModel) TableName() string {
 	return "users"
 }
+
+// AfterFind fills the full name of the user once it is loaded.
+func (user *UserModel) AfterFind(*gorm.DB) error {
+	user.FullName = strings.TrimSpace(user.FirstName + " " + user.LastName)
+	return nil
+}
+
+// AfterSave keeps the full name in sync with the saved names.
+func (user *UserModel) AfterSave(tx *gorm.DB) error {
+	return user.AfterFind(tx)
+}
@@ -0,0 +1,13 @@
+package channels
+
+// Recipient holds the addresses a notification can be delivered to, empty addresses are skipped.
+type Recipient struct {
+	Mobile string
+	Email  string
+}
+
+// Message is the content of a notification, channels without a subject only send the body.
+type Message struct {
+	Subject string
+	Body    string
+}
@@ -0,0 +1,18 @@
+package channels
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"log"
+	"time"
+)
+
+// Log prints notifications to the console, it is meant for development.
+type Log struct{}
+
+// Send prints the message alongside the addresses of the recipient.
+func (l *Log) Send(_ context.Context, recipient Recipient, message Message) error {
+	log.Println("Notification.", zap.String("mobile", recipient.Mobile), zap.String("email", recipient.Email),
+		zap.String("subject", message.Subject), zap.String("body", message.Body), zap.Time("timestamp", time.Now()))
+	return nil
+}
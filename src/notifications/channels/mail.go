@@ -0,0 +1,45 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Mail sends notifications by email through an smtp server.
+type Mail struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// Send emails the message to the recipient when they have an email address.
+func (m *Mail) Send(_ context.Context, recipient Recipient, message Message) error {
+	if recipient.Email == "" {
+		return nil
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	headers := []string{
+		"From: " + m.From,
+		"To: " + recipient.Email,
+		"Subject: " + mime.QEncoding.Encode("utf-8", message.Subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+	}
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + message.Body
+
+	if err := smtp.SendMail(net.JoinHostPort(m.Host, m.Port), auth, m.From, []string{recipient.Email}, []byte(body)); err != nil {
+		return fmt.Errorf("mail notification failed: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sms sends notifications as text messages through an http gateway,
+// the gateway receives a json body holding the mobile and the text.
+type Sms struct {
+	GatewayURL string
+	Token      string
+}
+
+var smsClient = &http.Client{Timeout: 10 * time.Second}
+
+// Send texts the message body to the recipient when they have a mobile.
+func (s *Sms) Send(ctx context.Context, recipient Recipient, message Message) error {
+	if recipient.Mobile == "" {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"mobile": recipient.Mobile,
+		"text":   message.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.GatewayURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("sms notification failed: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	res, err := smsClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms notification failed: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("sms notification failed: gateway responded %d", res.StatusCode)
+	}
+	return nil
+}
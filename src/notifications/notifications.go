@@ -0,0 +1,88 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/notifications/channels"
+	"strings"
+	"sync"
+)
+
+var (
+	connectOnce     sync.Once // Ensures the channels are initialised only once.
+	getInstanceOnce sync.Once // Ensures a single instance of Notifier is created.
+	instance        *Notifier // Holds the singleton instance of Notifier.
+)
+
+// INotificationChannel defines the interface for notification channels.
+// A channel skips recipients without an address it can deliver to.
+type INotificationChannel interface {
+	Send(ctx context.Context, recipient channels.Recipient, message channels.Message) error
+}
+
+// Notifier sends every notification through all the configured channels.
+type Notifier struct {
+	channels []INotificationChannel
+}
+
+// Init initializes the notifier by setting up the configured channels.
+func Init() (err error) {
+	return GetInstance().Connect()
+}
+
+// Connect sets up the channels listed in NOTIFICATION_CHANNELS, the log channel is used by default.
+func (notifier *Notifier) Connect() (err error) {
+	connectOnce.Do(func() {
+		configs := config.GetInstance() // Retrieve configurations
+
+		names := configs.Get("NOTIFICATION_CHANNELS")
+		if names == "" {
+			names = "log"
+		}
+
+		for _, name := range strings.Split(names, ",") {
+			switch strings.TrimSpace(name) {
+			case "log":
+				notifier.channels = append(notifier.channels, &channels.Log{})
+			case "mail":
+				notifier.channels = append(notifier.channels, &channels.Mail{
+					Host:     configs.Get("MAIL_HOST"),
+					Port:     configs.Get("MAIL_PORT"),
+					Username: configs.Get("MAIL_USERNAME"),
+					Password: configs.Get("MAIL_PASSWORD"),
+					From:     configs.Get("MAIL_FROM"),
+				})
+			case "sms":
+				notifier.channels = append(notifier.channels, &channels.Sms{
+					GatewayURL: configs.Get("SMS_GATEWAY_URL"),
+					Token:      configs.Get("SMS_GATEWAY_TOKEN"),
+				})
+			default:
+				err = errors.New("unsupported notification channel: " + name)
+				return
+			}
+		}
+	})
+
+	return
+}
+
+// Send delivers the message to the recipient through every channel and returns the failures of all of them.
+func (notifier *Notifier) Send(ctx context.Context, recipient channels.Recipient, message channels.Message) error {
+	var failures []error
+	for _, channel := range notifier.channels {
+		if err := channel.Send(ctx, recipient, message); err != nil {
+			failures = append(failures, err)
+		}
+	}
+	return errors.Join(failures...)
+}
+
+// GetInstance returns the singleton instance of the Notifier.
+func GetInstance() *Notifier {
+	getInstanceOnce.Do(func() {
+		instance = &Notifier{} // Initialize the singleton instance
+	})
+	return instance
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Refresh exchanges the refresh token for new tokens of the session.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	tokens := &Tokens{}
+	body := map[string]string{"refresh_token": refreshToken}
+	if err := c.do(ctx, http.MethodPost, "access-tokens/refresh", nil, body, "access_tokens", tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Sessions lists the sessions of the user, query holds the paging, sorting and filters.
+func (c *Client) Sessions(ctx context.Context, query url.Values) (*Page[Session], error) {
+	page := &Page[Session]{}
+	if err := c.do(ctx, http.MethodGet, "access-tokens", query, nil, "access_tokens", page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// ActiveSessions lists the sessions of the user which are not expired yet.
+func (c *Client) ActiveSessions(ctx context.Context, query url.Values) (*Page[Session], error) {
+	page := &Page[Session]{}
+	if err := c.do(ctx, http.MethodGet, "active-access-tokens", query, nil, "access_tokens", page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (c *Client) Session(ctx context.Context, uuid string) (*Session, error) {
+	session := &Session{}
+	if err := c.do(ctx, http.MethodGet, "access-tokens/"+url.PathEscape(uuid), nil, nil, "access_token", session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// RevokeSessions signs the user out everywhere, the session must have been stepped up recently.
+func (c *Client) RevokeSessions(ctx context.Context) error {
+	return c.do(ctx, http.MethodDelete, "access-tokens/revoke", nil, nil, "", nil)
+}
+
+func (c *Client) RevokeSession(ctx context.Context, uuid string) error {
+	return c.do(ctx, http.MethodDelete, "access-tokens/revoke/"+url.PathEscape(uuid), nil, nil, "", nil)
+}
+
+// RevokeCurrentSession signs out the session of the access token.
+func (c *Client) RevokeCurrentSession(ctx context.Context) error {
+	return c.do(ctx, http.MethodDelete, "access-tokens/revoke/current-token", nil, nil, "", nil)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SendOtp sends a one time password to the mobile and returns the key to verify it with.
+func (c *Client) SendOtp(ctx context.Context, req *SendOtpRequest) (string, error) {
+	var data struct {
+		Key string `json:"key"`
+	}
+	if err := c.do(ctx, http.MethodPost, "authentication/register/send-otp", nil, req, "", &data); err != nil {
+		return "", err
+	}
+	return data.Key, nil
+}
+
+// VerifyOtp verifies the one time password and opens a session of the user.
+func (c *Client) VerifyOtp(ctx context.Context, req *VerifyOtpRequest) (*Tokens, error) {
+	tokens := &Tokens{}
+	if err := c.do(ctx, http.MethodPost, "authentication/register/verify-otp", nil, req, "access_tokens", tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// TrustedDeviceLogin opens a session of a remembered device without a one time password.
+func (c *Client) TrustedDeviceLogin(ctx context.Context, req *TrustedDeviceLoginRequest) (*Tokens, error) {
+	tokens := &Tokens{}
+	if err := c.do(ctx, http.MethodPost, "authentication/trusted-device", nil, req, "access_tokens", tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// StepUpChallenge starts the re-authentication of the session and returns the method of the code to verify.
+func (c *Client) StepUpChallenge(ctx context.Context) (string, error) {
+	var data struct {
+		Method string `json:"method"`
+	}
+	if err := c.do(ctx, http.MethodPost, "authentication/step-up/challenge", nil, nil, "", &data); err != nil {
+		return "", err
+	}
+	return data.Method, nil
+}
+
+// StepUpVerify verifies the code of the challenge and marks the session as recently authenticated.
+func (c *Client) StepUpVerify(ctx context.Context, code string) (*StepUp, error) {
+	stepUp := &StepUp{}
+	body := map[string]string{"code": code}
+	if err := c.do(ctx, http.MethodPost, "authentication/step-up/verify", nil, body, "", stepUp); err != nil {
+		return nil, err
+	}
+	return stepUp, nil
+}
+
+// NotMe revokes the session of a new device notification and the device it trusted, with the parameters of its signed link.
+func (c *Client) NotMe(ctx context.Context, session, expires, signature string) error {
+	query := url.Values{"expires": {expires}, "signature": {signature}}
+	return c.do(ctx, http.MethodPost, "authentication/sessions/"+url.PathEscape(session)+"/not-me", query, nil, "", nil)
+}
+
+// ClientToken issues an access token to the client, narrowed down to the scopes when any are given.
+func (c *Client) ClientToken(ctx context.Context, clientID, clientSecret string, scopes ...string) (*ClientToken, error) {
+	body := map[string]string{
+		"grant_type":    "client_credentials",
+		"client_id":     clientID,
+		"client_secret": clientSecret,
+		"scope":         strings.Join(scopes, " "),
+	}
+
+	token := &ClientToken{}
+	if err := c.do(ctx, http.MethodPost, "authentication/token", nil, body, "", token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Introspect checks the access token of a user, the client must be sent with the access token of a client.
+func (c *Client) Introspect(ctx context.Context, accessToken string) (*Introspection, error) {
+	introspection := &Introspection{}
+	body := map[string]string{"token": accessToken}
+	if err := c.do(ctx, http.MethodPost, "authentication/introspect", nil, body, "introspection", introspection); err != nil {
+		return nil, err
+	}
+	return introspection, nil
+}
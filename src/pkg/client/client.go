@@ -0,0 +1,187 @@
+// Package client calls the http api of the authentication service from other Go services.
+// Every endpoint responds with the same envelope, the client decodes its data into typed values
+// and turns unsuccessful responses into *Error.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// apiPrefix is the path every endpoint is served under.
+const apiPrefix = "/api/v1/"
+
+// Response is the envelope of every response, Data holds the payload of successful ones.
+type Response struct {
+	IsSuccessful bool              `json:"is_successful"`
+	RequestUuid  string            `json:"request_uuid"`
+	RequestIp    string            `json:"request_ip"`
+	StatusCode   int               `json:"status_code"`
+	Message      string            `json:"message"`
+	Data         json.RawMessage   `json:"data,omitempty"`
+	Errors       map[string]string `json:"errors,omitempty"`
+	ErrorCode    int               `json:"error_code,omitempty"`
+}
+
+// Error is returned for the unsuccessful responses, Errors holds the validation errors of the fields.
+type Error struct {
+	StatusCode  int
+	Message     string
+	Errors      map[string]string
+	ErrorCode   int
+	RequestUuid string
+}
+
+func (e *Error) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("client: %d %s", e.StatusCode, e.Message)
+	}
+
+	fields := make([]string, 0, len(e.Errors))
+	for field, message := range e.Errors {
+		fields = append(fields, field+": "+message)
+	}
+	return fmt.Sprintf("client: %d %s (%s)", e.StatusCode, e.Message, strings.Join(fields, "; "))
+}
+
+// IsStatus reports whether err is an *Error of the status code.
+func IsStatus(err error, statusCode int) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == statusCode
+}
+
+// Client calls the endpoints, it is safe for concurrent use.
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	headers     http.Header
+	accessToken string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with the http client instead of one timing out after 10 seconds.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithLocale asks for the messages in the locale, fa or en.
+func WithLocale(locale string) Option {
+	return WithHeader("Accept-Language", locale)
+}
+
+// WithHeader sends the header with every request, like X-Device-Name or X-App-Version.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Set(key, value)
+	}
+}
+
+// New returns a client of the service at the base url, like https://auth.example.com.
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		headers:    http.Header{},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WithAccessToken returns a copy of the client sending the bearer access token, the endpoints of the
+// authenticated owner need one.
+func (c *Client) WithAccessToken(accessToken string) *Client {
+	clone := *c
+	clone.accessToken = accessToken
+	return &clone
+}
+
+// do sends the request and decodes the key of the response data into out, the whole data when the key is empty.
+// out may be nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, key string, out interface{}) error {
+	endpoint := c.baseURL + apiPrefix + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	for header, values := range c.headers {
+		req.Header[header] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	envelope := &Response{}
+	if err = json.NewDecoder(resp.Body).Decode(envelope); err != nil {
+		return &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+	if !envelope.IsSuccessful {
+		return &Error{
+			StatusCode:  resp.StatusCode,
+			Message:     envelope.Message,
+			Errors:      envelope.Errors,
+			ErrorCode:   envelope.ErrorCode,
+			RequestUuid: envelope.RequestUuid,
+		}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if key == "" {
+		if err = json.Unmarshal(envelope.Data, out); err != nil {
+			return fmt.Errorf("client: failed to decode the data: %w", err)
+		}
+		return nil
+	}
+	data := map[string]json.RawMessage{}
+	if len(envelope.Data) > 0 {
+		if err = json.Unmarshal(envelope.Data, &data); err != nil {
+			return fmt.Errorf("client: failed to decode the data: %w", err)
+		}
+	}
+	value, ok := data[key]
+	if !ok {
+		return fmt.Errorf("client: the response has no %s", key)
+	}
+	if err = json.Unmarshal(value, out); err != nil {
+		return fmt.Errorf("client: failed to decode %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package client
+
+import "time"
+
+type SendOtpRequest struct {
+	Mobile               string `json:"mobile"`
+	NationalIdentityCode string `json:"national_identity_code,omitempty"`
+}
+
+type VerifyOtpRequest struct {
+	Key            string `json:"key,omitempty"`
+	Otp            string `json:"otp"`
+	RememberDevice bool   `json:"remember_device,omitempty"`
+	DeviceID       string `json:"device_id,omitempty"`
+}
+
+type TrustedDeviceLoginRequest struct {
+	Mobile   string `json:"mobile"`
+	DeviceID string `json:"device_id"`
+	Token    string `json:"token"`
+}
+
+// Tokens are the tokens of a session, TrustedDeviceToken is only set when the device was remembered.
+type Tokens struct {
+	AccessToken           string    `json:"access_token_string"`
+	RefreshToken          string    `json:"refresh_token_string"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	Scopes                []string  `json:"scopes,omitempty"`
+	TrustedDeviceToken    string    `json:"trusted_device_token,omitempty"`
+	TokenType             string    `json:"token_type,omitempty"`
+}
+
+// ClientToken is the access token of a client, clients get no refresh token and ask for a new one.
+type ClientToken struct {
+	AccessToken          string    `json:"access_token"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	TokenType            string    `json:"token_type"`
+	Scopes               []string  `json:"scopes"`
+}
+
+// StepUp tells when and how the session was last authenticated.
+type StepUp struct {
+	AuthTime *time.Time `json:"auth_time"`
+	Acr      string     `json:"acr"`
+}
+
+// Introspection describes an access token of a user, inactive tokens only have Active set to false.
+// Tokens bound to a DPoP key have their thumbprint in DPoPJkt and impersonation tokens name the admin in Actor.
+type Introspection struct {
+	Active      bool       `json:"active"`
+	SessionUuid string     `json:"session_uuid,omitempty"`
+	UserUuid    string     `json:"user_uuid,omitempty"`
+	Permissions []string   `json:"permissions,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	AuthTime    *time.Time `json:"auth_time,omitempty"`
+	Acr         string     `json:"acr,omitempty"`
+	DPoPJkt     string     `json:"dpop_jkt,omitempty"`
+	Actor       string     `json:"actor,omitempty"`
+}
+
+// Session is a session of the owner, the access tokens themselves are never returned.
+type Session struct {
+	Uuid                  string     `json:"uuid"`
+	AccessTokenExpiresAt  time.Time  `json:"access_token_expires_at"`
+	RefreshTokenExpiresAt time.Time  `json:"refresh_token_expires_at"`
+	IP                    string     `json:"ip"`
+	UserAgent             string     `json:"user_agent"`
+	DeviceName            string     `json:"device_name"`
+	DeviceType            string     `json:"device_type"`
+	Platform              string     `json:"platform"`
+	OS                    string     `json:"os"`
+	OSVersion             string     `json:"os_version"`
+	Browser               string     `json:"browser"`
+	BrowserVersion        string     `json:"browser_version"`
+	AppVersion            string     `json:"app_version"`
+	CountryCode           string     `json:"country_code"`
+	Country               string     `json:"country"`
+	City                  string     `json:"city"`
+	Scopes                []string   `json:"scopes"`
+	AuthTime              *time.Time `json:"auth_time"`
+	Acr                   string     `json:"acr"`
+	DPoPJkt               string     `json:"dpop_jkt"`
+	LastUsedAt            *time.Time `json:"last_used_at"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+type User struct {
+	Uuid                 string     `json:"uuid"`
+	IsActive             bool       `json:"is_active"`
+	FirstName            string     `json:"first_name"`
+	LastName             string     `json:"last_name"`
+	FullName             string     `json:"full_name"`
+	FatherName           string     `json:"father_name"`
+	ProfileImage         string     `json:"profile_image"`
+	NationalIdentityCode string     `json:"national_identity_code"`
+	Mobile               string     `json:"mobile"`
+	Email                string     `json:"email"`
+	DeletionRequestedAt  *time.Time `json:"deletion_requested_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// Page is a page of a list, the lists take the page, page_size, sort_by and sort_order query parameters.
+type Page[T any] struct {
+	PageSize    uint  `json:"page_size"`
+	CurrentPage uint  `json:"current_page"`
+	TotalPages  int64 `json:"total_pages"`
+	TotalItems  int64 `json:"total_items"`
+	Items       []T   `json:"items"`
+}
@@ -0,0 +1,15 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Me returns the user of the access token.
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	user := &User{}
+	if err := c.do(ctx, http.MethodGet, "users/me", nil, nil, "user", user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,169 @@
+// Package dpop parses the DPoP proofs of RFC 9449, the signed jwts a client sends to prove it holds the key
+// its tokens are bound to. The public key travels in the jwk header and is identified by its RFC 7638 thumbprint.
+package dpop
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"time"
+)
+
+// ProofType is the typ header of every proof.
+const ProofType = "dpop+jwt"
+
+var ErrInvalidProof = errors.New("invalid dpop proof")
+
+// algorithms are the asymmetric signing algorithms accepted for the proofs.
+var algorithms = []string{"ES256", "ES384", "RS256", "PS256", "EdDSA"}
+
+// Proof holds the claims of a verified proof.
+type Proof struct {
+	ID              string    // ID is the unique jti of the proof.
+	Method          string    // Method is the htm, the http method of the request.
+	URL             string    // URL is the htu, the url of the request without query and fragment.
+	IssuedAt        time.Time // IssuedAt is the iat of the proof.
+	AccessTokenHash string    // AccessTokenHash is the ath, sent along access tokens only.
+	Thumbprint      string    // Thumbprint is the jwk thumbprint of the key which signed the proof.
+}
+
+type claims struct {
+	Method          string `json:"htm"`
+	URL             string `json:"htu"`
+	AccessTokenHash string `json:"ath,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Parse verifies the signature of the proof with the key of its jwk header and returns its claims.
+// The caller still checks the method, url, freshness and uniqueness of the proof against the request.
+func Parse(proof string) (*Proof, error) {
+	var thumbprint string
+	parsed := &claims{}
+
+	_, err := jwt.ParseWithClaims(proof, parsed, func(token *jwt.Token) (interface{}, error) {
+		if token.Header["typ"] != ProofType {
+			return nil, errors.New("unexpected typ header")
+		}
+
+		jwk, ok := token.Header["jwk"].(map[string]interface{})
+		if !ok {
+			return nil, errors.New("missing jwk header")
+		}
+
+		key, keyThumbprint, err := publicKey(jwk)
+		if err != nil {
+			return nil, err
+		}
+		thumbprint = keyThumbprint
+		return key, nil
+	}, jwt.WithValidMethods(algorithms))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+
+	result := &Proof{
+		ID:              parsed.ID,
+		Method:          parsed.Method,
+		URL:             parsed.URL,
+		AccessTokenHash: parsed.AccessTokenHash,
+		Thumbprint:      thumbprint,
+	}
+	if parsed.IssuedAt != nil {
+		result.IssuedAt = parsed.IssuedAt.Time
+	}
+	if result.ID == "" || result.Method == "" || result.URL == "" || result.IssuedAt.IsZero() {
+		return nil, fmt.Errorf("%w: missing claims", ErrInvalidProof)
+	}
+	return result, nil
+}
+
+// AccessTokenHash returns the ath a proof must carry when sent along the access token.
+func AccessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// publicKey builds the public key of the jwk and computes its thumbprint from the required members only.
+func publicKey(jwk map[string]interface{}) (interface{}, string, error) {
+	if _, ok := jwk["d"]; ok {
+		return nil, "", errors.New("jwk holds a private key")
+	}
+
+	member := func(name string) string {
+		value, _ := jwk[name].(string)
+		return value
+	}
+
+	switch member("kty") {
+	case "EC":
+		var curve elliptic.Curve
+		switch member("crv") {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, "", errors.New("unsupported curve")
+		}
+
+		x, errX := decodeInt(member("x"))
+		y, errY := decodeInt(member("y"))
+		if errX != nil || errY != nil || !curve.IsOnCurve(x, y) {
+			return nil, "", errors.New("invalid ec key")
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		return key, thumbprint("crv", member("crv"), "kty", "EC", "x", member("x"), "y", member("y")), nil
+
+	case "RSA":
+		n, errN := decodeInt(member("n"))
+		e, errE := decodeInt(member("e"))
+		if errN != nil || errE != nil || n.BitLen() < 2048 || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, "", errors.New("invalid rsa key")
+		}
+		key := &rsa.PublicKey{N: n, E: int(e.Int64())}
+		return key, thumbprint("e", member("e"), "kty", "RSA", "n", member("n")), nil
+
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(member("x"))
+		if member("crv") != "Ed25519" || err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, "", errors.New("invalid okp key")
+		}
+		return ed25519.PublicKey(x), thumbprint("crv", "Ed25519", "kty", "OKP", "x", member("x")), nil
+
+	default:
+		return nil, "", errors.New("unsupported key type")
+	}
+}
+
+// thumbprint hashes the members, given as name value pairs in lexicographic order, as RFC 7638 describes.
+func thumbprint(members ...string) string {
+	canonical := []byte("{")
+	for i := 0; i < len(members); i += 2 {
+		if i > 0 {
+			canonical = append(canonical, ',')
+		}
+		name, _ := json.Marshal(members[i])
+		value, _ := json.Marshal(members[i+1])
+		canonical = append(append(append(canonical, name...), ':'), value...)
+	}
+	canonical = append(canonical, '}')
+
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func decodeInt(value string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(raw) == 0 {
+		return nil, errors.New("invalid integer")
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
@@ -0,0 +1,155 @@
+package dpop
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestThumbprintRFC7638(t *testing.T) {
+	// the example key of RFC 7638 section 3.1, with the optional members it lists
+	jwk := map[string]interface{}{
+		"kty": "RSA",
+		"n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMs" +
+			"tn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91" +
+			"CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		"e":   "AQAB",
+		"alg": "RS256",
+		"kid": "2011-04-29",
+	}
+
+	_, got, err := publicKey(jwk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"; got != want {
+		t.Errorf("thumbprint = %q, want %q", got, want)
+	}
+}
+
+// ecJWK returns the public jwk of the P-256 key.
+func ecJWK(key *ecdsa.PrivateKey) map[string]interface{} {
+	return map[string]interface{}{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+// sign builds a proof with the given headers over the claims.
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, headers map[string]interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	for name, value := range headers {
+		token.Header[name] = value
+	}
+	proof, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return proof
+}
+
+func TestParse(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edJWK := map[string]interface{}{"kty": "OKP", "crv": "Ed25519", "x": base64.RawURLEncoding.EncodeToString(edPublic)}
+
+	now := time.Now()
+	valid := jwt.MapClaims{"jti": "proof-1", "htm": "POST", "htu": "https://auth.example.com/api/v1/authentication/token", "iat": now.Unix()}
+
+	withPrivate := ecJWK(ecKey)
+	withPrivate["d"] = base64.RawURLEncoding.EncodeToString(ecKey.D.Bytes())
+
+	offCurve := ecJWK(ecKey)
+	y := new(big.Int).Add(ecKey.Y, big.NewInt(1))
+	offCurve["y"] = base64.RawURLEncoding.EncodeToString(y.FillBytes(make([]byte, 32)))
+
+	unknownCurve := ecJWK(ecKey)
+	unknownCurve["crv"] = "P-521"
+
+	tests := []struct {
+		name    string
+		method  jwt.SigningMethod
+		key     interface{}
+		headers map[string]interface{}
+		claims  jwt.MapClaims
+		valid   bool
+	}{
+		{"es256", jwt.SigningMethodES256, ecKey, map[string]interface{}{"typ": ProofType, "jwk": ecJWK(ecKey)}, valid, true},
+		{"eddsa", jwt.SigningMethodEdDSA, edKey, map[string]interface{}{"typ": ProofType, "jwk": edJWK}, valid, true},
+		{"jwk holding d", jwt.SigningMethodES256, ecKey, map[string]interface{}{"typ": ProofType, "jwk": withPrivate}, valid, false},
+		{"jwt typ", jwt.SigningMethodES256, ecKey, map[string]interface{}{"typ": "JWT", "jwk": ecJWK(ecKey)}, valid, false},
+		{"missing typ", jwt.SigningMethodES256, ecKey, map[string]interface{}{"typ": nil, "jwk": ecJWK(ecKey)}, valid, false},
+		{"hs256", jwt.SigningMethodHS256, []byte("secret"), map[string]interface{}{"typ": ProofType, "jwk": ecJWK(ecKey)}, valid, false},
+		{"off-curve point", jwt.SigningMethodES256, ecKey, map[string]interface{}{"typ": ProofType, "jwk": offCurve}, valid, false},
+		{"unknown curve", jwt.SigningMethodES256, ecKey, map[string]interface{}{"typ": ProofType, "jwk": unknownCurve}, valid, false},
+		{"missing jwk", jwt.SigningMethodES256, ecKey, map[string]interface{}{"typ": ProofType}, valid, false},
+		{"other key in jwk", jwt.SigningMethodEdDSA, edKey, map[string]interface{}{"typ": ProofType, "jwk": ecJWK(ecKey)}, valid, false},
+		{"missing jti", jwt.SigningMethodES256, ecKey, map[string]interface{}{"typ": ProofType, "jwk": ecJWK(ecKey)},
+			jwt.MapClaims{"htm": "POST", "htu": "https://auth.example.com/api/v1/authentication/token", "iat": now.Unix()}, false},
+		{"missing iat", jwt.SigningMethodES256, ecKey, map[string]interface{}{"typ": ProofType, "jwk": ecJWK(ecKey)},
+			jwt.MapClaims{"jti": "proof-1", "htm": "POST", "htu": "https://auth.example.com/api/v1/authentication/token"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proof, err := Parse(sign(t, test.method, test.key, test.headers, test.claims))
+			if !test.valid {
+				if !errors.Is(err, ErrInvalidProof) {
+					t.Fatalf("Parse = %v, %v, want ErrInvalidProof", proof, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			_, want, _ := publicKey(test.headers["jwk"].(map[string]interface{}))
+			if proof.Thumbprint != want || proof.ID != "proof-1" || proof.Method != "POST" || proof.IssuedAt.Unix() != now.Unix() {
+				t.Errorf("Parse = %+v, want the claims and thumbprint %s", proof, want)
+			}
+		})
+	}
+}
+
+func TestParseRefusesTamperedProof(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	proof := sign(t, jwt.SigningMethodES256, key, map[string]interface{}{"typ": ProofType, "jwk": ecJWK(key)},
+		jwt.MapClaims{"jti": "proof-1", "htm": "POST", "htu": "https://auth.example.com/", "iat": time.Now().Unix()})
+
+	// the signature of another key over the same header and claims
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	signed := proof[:strings.LastIndex(proof, ".")]
+	signature, err := jwt.SigningMethodES256.Sign(signed, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = Parse(signed + "." + base64.RawURLEncoding.EncodeToString(signature)); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("Parse = %v, want ErrInvalidProof", err)
+	}
+}
+
+func TestAccessTokenHash(t *testing.T) {
+	// the ath of the access token in the examples of RFC 9449 section 7.1
+	token := "Kz~8mXK1EalYznwH-LC-1fBAo.4Ljp~zsPE_NeO.gxU"
+	if got, want := AccessTokenHash(token), "fUHyO2r2Z3DZ53EsNrWBb0xWXoaNy59IiKCAqksmQEo"; got != want {
+		t.Errorf("AccessTokenHash = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+// Package imaging decodes uploaded images and re-encodes them as clean jpegs.
+// Re-encoding drops every metadata segment of the original file, EXIF included, so the EXIF orientation
+// is applied to the pixels on decoding.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"slices"
+)
+
+// MaxPixels bounds the decoded size of an image to protect against decompression bombs.
+const MaxPixels = 40_000_000
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrInvalidImage    = errors.New("invalid image")
+)
+
+// AllowedContentTypes are the sniffed content types accepted by Decode.
+var AllowedContentTypes = []string{"image/jpeg", "image/png"}
+
+// Decode sniffs the content type of the data and decodes it upright.
+func Decode(data []byte) (image.Image, error) {
+	if !slices.Contains(AllowedContentTypes, http.DetectContentType(data)) {
+		return nil, ErrUnsupportedType
+	}
+
+	// check the dimensions before allocating the whole image
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxPixels {
+		return nil, ErrInvalidImage
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	return orient(img, orientation(data)), nil
+}
+
+// EncodeJPEG flattens the image on a white background and encodes it as a jpeg.
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	flattened := image.NewRGBA(img.Bounds())
+	draw.Draw(flattened, flattened.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(flattened, flattened.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, flattened, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Fit scales the image down so neither side exceeds maxSize, keeping its aspect ratio.
+// Images already fitting are returned untouched.
+func Fit(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*maxSize/width)
+		width = maxSize
+	} else {
+		width = max(1, width*maxSize/height)
+		height = maxSize
+	}
+	return resize(img, width, height)
+}
+
+// resize downsamples the image with a box filter, averaging every source pixel covered by a destination pixel.
+func resize(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcHeight/height)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcWidth/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcWidth/width)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / count),
+				G: uint16(g / count),
+				B: uint16(b / count),
+				A: uint16(a / count),
+			})
+		}
+	}
+	return dst
+}
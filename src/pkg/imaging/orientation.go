@@ -0,0 +1,133 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// exifOrientationTag is the tag of the orientation in the first image file directory of the EXIF data.
+const exifOrientationTag = 0x0112
+
+// orientation reads the EXIF orientation of a jpeg, from 1 (upright) to 8, and 1 when the data has none.
+func orientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			// fill byte
+			i++
+			continue
+		case marker == 0x01 || marker >= 0xD0 && marker <= 0xD8:
+			// markers without a length
+			i += 2
+			continue
+		case marker == 0xDA || marker == 0xD9:
+			// the metadata comes before the scan
+			return 1
+		}
+
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if size < 2 || i+2+size > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+// exifOrientation reads the orientation tag from the tiff structure of the EXIF data.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 1
+	}
+
+	ifd := int64(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > int64(len(tiff)) {
+		return 1
+	}
+	entries := int64(order.Uint16(tiff[ifd:]))
+	for i := int64(0); i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > int64(len(tiff)) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+
+		// the orientation is a SHORT, held in the first bytes of the value field
+		if order.Uint16(tiff[entry+2:]) != 3 {
+			return 1
+		}
+		value := int(order.Uint16(tiff[entry+8:]))
+		if value < 1 || value > 8 {
+			return 1
+		}
+		return value
+	}
+	return 1
+}
+
+// orient rotates and flips the image the way the EXIF orientation says it should be displayed.
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored
+				sx, sy = width-1-x, y
+			case 3: // rotated 180°
+				sx, sy = width-1-x, height-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, height-1-y
+			case 5: // mirrored and rotated 270° clockwise
+				sx, sy = y, x
+			case 6: // rotated 90° clockwise
+				sx, sy = y, height-1-x
+			case 7: // mirrored and rotated 90° clockwise
+				sx, sy = width-1-y, height-1-x
+			case 8: // rotated 270° clockwise
+				sx, sy = width-1-y, x
+			}
+			dst.Set(x, y, img.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+	return dst
+}
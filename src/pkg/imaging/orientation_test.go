@@ -0,0 +1,135 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+func TestOrient(t *testing.T) {
+	// a 3x2 image whose top left pixel is red and top right pixel is blue
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, red)
+	src.Set(2, 0, blue)
+
+	tests := []struct {
+		orientation   int
+		width, height int
+		red, blue     image.Point
+	}{
+		{1, 3, 2, image.Pt(0, 0), image.Pt(2, 0)},
+		{2, 3, 2, image.Pt(2, 0), image.Pt(0, 0)},
+		{3, 3, 2, image.Pt(2, 1), image.Pt(0, 1)},
+		{4, 3, 2, image.Pt(0, 1), image.Pt(2, 1)},
+		{5, 2, 3, image.Pt(0, 0), image.Pt(0, 2)},
+		{6, 2, 3, image.Pt(1, 0), image.Pt(1, 2)},
+		{7, 2, 3, image.Pt(1, 2), image.Pt(1, 0)},
+		{8, 2, 3, image.Pt(0, 2), image.Pt(0, 0)},
+	}
+
+	for _, test := range tests {
+		img := orient(src, test.orientation)
+		if got := img.Bounds().Size(); got != image.Pt(test.width, test.height) {
+			t.Errorf("orientation %d: size = %v, want %dx%d", test.orientation, got, test.width, test.height)
+			continue
+		}
+		if got := color.RGBAModel.Convert(img.At(test.red.X, test.red.Y)); got != red {
+			t.Errorf("orientation %d: pixel %v = %v, want red", test.orientation, test.red, got)
+		}
+		if got := color.RGBAModel.Convert(img.At(test.blue.X, test.blue.Y)); got != blue {
+			t.Errorf("orientation %d: pixel %v = %v, want blue", test.orientation, test.blue, got)
+		}
+	}
+}
+
+// withOrientation inserts an EXIF segment holding the orientation right after the start of the jpeg.
+func withOrientation(t *testing.T, jpg []byte, order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8) // first image file directory
+	order.PutUint16(tiff[8:], 1) // one entry
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], 3) // SHORT
+	order.PutUint32(tiff[14:], 1) // one value
+	order.PutUint16(tiff[18:], orientation)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	if !bytes.HasPrefix(jpg, []byte{0xFF, 0xD8}) {
+		t.Fatal("not a jpeg")
+	}
+	return append(append(append([]byte{0xFF, 0xD8}, app1...), segment...), jpg[2:]...)
+}
+
+func TestOrientation(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, image.NewRGBA(image.Rect(0, 0, 8, 8)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		for value := uint16(1); value <= 8; value++ {
+			if got := orientation(withOrientation(t, buffer.Bytes(), order, value)); got != int(value) {
+				t.Errorf("%v orientation %d read as %d", order, value, got)
+			}
+		}
+	}
+
+	if got := orientation(buffer.Bytes()); got != 1 {
+		t.Errorf("orientation without EXIF = %d, want 1", got)
+	}
+	if got := orientation(withOrientation(t, buffer.Bytes(), binary.BigEndian, 9)); got != 1 {
+		t.Errorf("orientation 9 read as %d, want 1", got)
+	}
+	if got := orientation([]byte{0x89, 'P', 'N', 'G'}); got != 1 {
+		t.Errorf("orientation of a png = %d, want 1", got)
+	}
+}
+
+func TestDecodeAppliesOrientation(t *testing.T) {
+	// a 16x8 photo taken in portrait, stored sideways with its top on the right
+	src := image.NewRGBA(image.Rect(0, 0, 16, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			if x < 8 {
+				src.Set(x, y, red)
+			} else {
+				src.Set(x, y, blue)
+			}
+		}
+	}
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, src, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := Decode(withOrientation(t, buffer.Bytes(), binary.BigEndian, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(8, 16) {
+		t.Fatalf("size = %v, want 8x16", got)
+	}
+
+	// rotated 270° clockwise the right half is on top
+	top, _, topBlue, _ := img.At(4, 4).RGBA()
+	bottom, _, bottomBlue, _ := img.At(4, 12).RGBA()
+	if top > topBlue || bottom < bottomBlue {
+		t.Errorf("top = %v, bottom = %v, want blue over red", img.At(4, 4), img.At(4, 12))
+	}
+}
@@ -0,0 +1,373 @@
+// Package mmdb reads databases in the MaxMind DB format, like the GeoLite2 and DB-IP files.
+// The whole file is loaded in memory and lookups decode the record of the network holding the ip.
+package mmdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"os"
+)
+
+var (
+	ErrInvalidDatabase = errors.New("invalid mmdb database")
+	ErrInvalidIP       = errors.New("invalid ip address")
+)
+
+// metadataMarker precedes the metadata section at the end of the file.
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparator is the size of the zeroed bytes between the search tree and the data section.
+const dataSectionSeparator = 16
+
+// Metadata describes the database, it is read from the end of the file.
+type Metadata struct {
+	NodeCount    uint
+	RecordSize   uint
+	IPVersion    uint
+	DatabaseType string
+	BuildEpoch   uint64
+}
+
+// Reader looks ip addresses up in a database.
+type Reader struct {
+	Metadata  Metadata
+	tree      []byte
+	data      []byte
+	ipv4Start uint
+}
+
+// Open loads the database file.
+func Open(path string) (*Reader, error) {
+	buffer, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return FromBytes(buffer)
+}
+
+// FromBytes reads a database already loaded in memory.
+func FromBytes(buffer []byte) (*Reader, error) {
+	start := bytes.LastIndex(buffer, metadataMarker)
+	if start == -1 {
+		return nil, ErrInvalidDatabase
+	}
+
+	metadataSection := buffer[start+len(metadataMarker):]
+	value, _, err := (&decoder{buffer: metadataSection}).decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDatabase, err)
+	}
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidDatabase
+	}
+
+	metadata := Metadata{
+		NodeCount:    uint(toUint64(fields["node_count"])),
+		RecordSize:   uint(toUint64(fields["record_size"])),
+		IPVersion:    uint(toUint64(fields["ip_version"])),
+		BuildEpoch:   toUint64(fields["build_epoch"]),
+		DatabaseType: fmt.Sprint(fields["database_type"]),
+	}
+	if metadata.RecordSize != 24 && metadata.RecordSize != 28 && metadata.RecordSize != 32 {
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, metadata.RecordSize)
+	}
+
+	treeSize := metadata.NodeCount * metadata.RecordSize / 4
+	if treeSize+dataSectionSeparator > uint(start) {
+		return nil, ErrInvalidDatabase
+	}
+
+	reader := &Reader{
+		Metadata: metadata,
+		tree:     buffer[:treeSize],
+		data:     buffer[treeSize+dataSectionSeparator : start],
+	}
+
+	// ipv4 addresses live under ::/96 of an ipv6 tree
+	if metadata.IPVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < metadata.NodeCount; i++ {
+			node = reader.readRecord(node, 0)
+		}
+		reader.ipv4Start = node
+	}
+
+	return reader, nil
+}
+
+// Lookup returns the record of the network holding the ip, or nil when the ip is not in the database.
+func (r *Reader) Lookup(ip net.IP) (map[string]interface{}, error) {
+	address, node, err := r.startNode(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	bitCount := uint(len(address) * 8)
+	for i := uint(0); i < bitCount && node < r.Metadata.NodeCount; i++ {
+		bit := uint(address[i/8]>>(7-i%8)) & 1
+		node = r.readRecord(node, bit)
+	}
+
+	if node == r.Metadata.NodeCount {
+		return nil, nil
+	}
+	if node < r.Metadata.NodeCount+dataSectionSeparator {
+		return nil, ErrInvalidDatabase
+	}
+
+	offset := node - r.Metadata.NodeCount - dataSectionSeparator
+	value, _, err := (&decoder{buffer: r.data}).decode(offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDatabase, err)
+	}
+
+	record, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidDatabase
+	}
+	return record, nil
+}
+
+// startNode returns the address bytes to walk and the node to start from.
+func (r *Reader) startNode(ip net.IP) ([]byte, uint, error) {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		if r.Metadata.IPVersion == 6 {
+			return ipv4, r.ipv4Start, nil
+		}
+		return ipv4, 0, nil
+	}
+
+	ipv6 := ip.To16()
+	if ipv6 == nil {
+		return nil, 0, ErrInvalidIP
+	}
+	if r.Metadata.IPVersion == 4 {
+		return nil, 0, fmt.Errorf("%w: ipv6 lookup in an ipv4 database", ErrInvalidIP)
+	}
+	return ipv6, 0, nil
+}
+
+// readRecord returns the left (bit 0) or right (bit 1) record of the node.
+func (r *Reader) readRecord(node, bit uint) uint {
+	switch r.Metadata.RecordSize {
+	case 24:
+		b := r.tree[node*6+bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		b := r.tree[node*7:]
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		return uint(binary.BigEndian.Uint32(r.tree[node*8+bit*4:]))
+	}
+}
+
+// data types of the data section
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBool
+	typeFloat
+)
+
+type decoder struct {
+	buffer []byte
+}
+
+// decode returns the value at the offset and the offset following it.
+func (d *decoder) decode(offset uint) (interface{}, uint, error) {
+	if offset >= uint(len(d.buffer)) {
+		return nil, 0, errors.New("offset out of range")
+	}
+
+	control := d.buffer[offset]
+	offset++
+
+	kind := uint(control >> 5)
+	if kind == typePointer {
+		pointer, next, err := d.pointer(control, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		// pointers never point to pointers, this also keeps a corrupted file from looping
+		if pointer < uint(len(d.buffer)) && uint(d.buffer[pointer]>>5) == typePointer {
+			return nil, 0, errors.New("pointer to a pointer")
+		}
+		value, _, err := d.decode(pointer)
+		return value, next, err
+	}
+
+	if kind == typeExtended {
+		if offset >= uint(len(d.buffer)) {
+			return nil, 0, errors.New("offset out of range")
+		}
+		kind = 7 + uint(d.buffer[offset])
+		offset++
+	}
+
+	size, offset, err := d.size(control, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if kind == typeMap {
+		return d.decodeMap(size, offset)
+	}
+	if kind == typeArray {
+		return d.decodeArray(size, offset)
+	}
+	if kind == typeBool {
+		return size != 0, offset, nil
+	}
+
+	end := offset + size
+	if end > uint(len(d.buffer)) {
+		return nil, 0, errors.New("value out of range")
+	}
+	raw := d.buffer[offset:end]
+
+	switch kind {
+	case typeString:
+		return string(raw), end, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, errors.New("invalid double size")
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(raw)), end, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, errors.New("invalid float size")
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(raw))), end, nil
+	case typeBytes:
+		return append([]byte(nil), raw...), end, nil
+	case typeUint16, typeUint32, typeUint64:
+		var value uint64
+		for _, b := range raw {
+			value = value<<8 | uint64(b)
+		}
+		return value, end, nil
+	case typeInt32:
+		var value uint32
+		for _, b := range raw {
+			value = value<<8 | uint32(b)
+		}
+		return int64(int32(value)), end, nil
+	case typeUint128:
+		return new(big.Int).SetBytes(raw), end, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported data type %d", kind)
+	}
+}
+
+// pointer resolves the pointer encoded in the control byte and the bytes following it.
+func (d *decoder) pointer(control byte, offset uint) (uint, uint, error) {
+	length := uint((control>>3)&0x3) + 1
+	if offset+length > uint(len(d.buffer)) {
+		return 0, 0, errors.New("pointer out of range")
+	}
+
+	var pointer uint
+	if length != 4 {
+		pointer = uint(control & 0x7)
+	}
+	for _, b := range d.buffer[offset : offset+length] {
+		pointer = pointer<<8 | uint(b)
+	}
+
+	switch length {
+	case 2:
+		pointer += 2048
+	case 3:
+		pointer += 526336
+	}
+	return pointer, offset + length, nil
+}
+
+// size reads the payload size encoded in the control byte and the bytes following it.
+func (d *decoder) size(control byte, offset uint) (uint, uint, error) {
+	size := uint(control & 0x1f)
+	if size < 29 {
+		return size, offset, nil
+	}
+
+	length := size - 28
+	if offset+length > uint(len(d.buffer)) {
+		return 0, 0, errors.New("size out of range")
+	}
+
+	var extra uint
+	for _, b := range d.buffer[offset : offset+length] {
+		extra = extra<<8 | uint(b)
+	}
+
+	switch size {
+	case 29:
+		return 29 + extra, offset + length, nil
+	case 30:
+		return 285 + extra, offset + length, nil
+	default:
+		return 65821 + extra, offset + length, nil
+	}
+}
+
+func (d *decoder) decodeMap(size, offset uint) (interface{}, uint, error) {
+	result := make(map[string]interface{}, size)
+	for i := uint(0); i < size; i++ {
+		key, next, err := d.decode(offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, 0, errors.New("map key is not a string")
+		}
+
+		value, next, err := d.decode(next)
+		if err != nil {
+			return nil, 0, err
+		}
+		result[name] = value
+		offset = next
+	}
+	return result, offset, nil
+}
+
+func (d *decoder) decodeArray(size, offset uint) (interface{}, uint, error) {
+	result := make([]interface{}, 0, size)
+	for i := uint(0); i < size; i++ {
+		value, next, err := d.decode(offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		result = append(result, value)
+		offset = next
+	}
+	return result, offset, nil
+}
+
+// toUint64 converts the unsigned integers of the metadata.
+func toUint64(value interface{}) uint64 {
+	number, _ := value.(uint64)
+	return number
+}
@@ -0,0 +1,47 @@
+// Package permissions lists every permission known to the application.
+// Seeders create them in the database and routes refer to them by these names.
+package permissions
+
+const (
+	// SuperAdminRole is granted every permission by the seeders and can not be changed through the api.
+	SuperAdminRole = "super-admin"
+)
+
+const (
+	UsersList        = "users.list"
+	UsersShow        = "users.show"
+	UsersCreate      = "users.create"
+	UsersUpdate      = "users.update"
+	UsersDeactivate  = "users.deactivate"
+	UsersDelete      = "users.delete"
+	UsersRestore     = "users.restore"
+	UsersForceDelete = "users.force-delete"
+	UsersImpersonate = "users.impersonate"
+	SessionsList     = "sessions.list"
+	SessionsRevoke   = "sessions.revoke"
+	RolesList        = "roles.list"
+	RolesAssign      = "roles.assign"
+	PermissionsList  = "permissions.list"
+	AuditEventsList  = "audit-events.list"
+)
+
+// All returns every permission alongside its human-readable title.
+func All() map[string]string {
+	return map[string]string{
+		UsersList:        "List users",
+		UsersShow:        "Show a user",
+		UsersCreate:      "Create users",
+		UsersUpdate:      "Update users",
+		UsersDeactivate:  "Deactivate and reactivate users",
+		UsersDelete:      "Delete users",
+		UsersRestore:     "Restore deleted users",
+		UsersForceDelete: "Permanently delete users",
+		UsersImpersonate: "Impersonate users",
+		SessionsList:     "List sessions of users",
+		SessionsRevoke:   "Revoke sessions of users",
+		RolesList:        "List roles",
+		RolesAssign:      "Assign and revoke roles",
+		PermissionsList:  "List permissions",
+		AuditEventsList:  "List audit events",
+	}
+}
@@ -0,0 +1,91 @@
+// Package totp implements time-based one-time passwords as described in RFC 6238,
+// compatible with the common authenticator applications.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"go-auth-otp-service/src/pkg/utils"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the number of digits of a generated code.
+	Digits = 6
+	// Period is the number of seconds a code is valid for.
+	Period = 30
+	// Skew is the number of periods before and after the current one that are still accepted.
+	Skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret generates a new random base32 encoded secret.
+func GenerateSecret() (string, error) {
+	secret, err := utils.GenerateSalt(20)
+	if err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// Code returns the code of the secret for the given time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/Period))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%1000000), nil
+}
+
+// Validate checks the code against the secret, allowing for a small clock skew.
+func Validate(secret, code string) bool {
+	_, ok := ValidateStep(secret, code, 0)
+	return ok
+}
+
+// ValidateStep checks the code like Validate and returns the time step it belongs to. Only the steps after the
+// given one are accepted, so storing the returned step prevents a code from being used twice.
+func ValidateStep(secret, code string, after int64) (int64, bool) {
+	now := time.Now()
+	for i := -Skew; i <= Skew; i++ {
+		t := now.Add(time.Duration(i*Period) * time.Second)
+		expected, err := Code(secret, t)
+		if err != nil {
+			return 0, false
+		}
+		if step := t.Unix() / Period; step > after && subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// URL returns the otpauth url used by authenticator applications to enroll the secret.
+func URL(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(Period))
+
+	return fmt.Sprintf("otpauth://totp/%s:%s?%s", url.PathEscape(issuer), url.PathEscape(account), query.Encode())
+}
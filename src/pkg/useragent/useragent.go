@@ -0,0 +1,130 @@
+// Package useragent extracts the browser, operating system and device type from a user agent string.
+// It recognises the common browsers and platforms only, anything else is reported as unknown.
+package useragent
+
+import (
+	"regexp"
+	"strings"
+)
+
+// device types
+const (
+	Desktop = "desktop"
+	Mobile  = "mobile"
+	Tablet  = "tablet"
+	Bot     = "bot"
+	Unknown = "unknown"
+)
+
+// UserAgent holds the information parsed from a user agent string.
+type UserAgent struct {
+	Browser        string
+	BrowserVersion string
+	OS             string
+	OSVersion      string
+	DeviceType     string
+}
+
+type pattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// browsers are matched in order, the ones built on chrome or safari must come before them.
+var browsers = []pattern{
+	{"Edge", regexp.MustCompile(`(?:Edg|EdgA|EdgiOS|Edge)/([\d.]+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|OPiOS|Opera)/([\d.]+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/([\d.]+)`)},
+	{"Yandex", regexp.MustCompile(`YaBrowser/([\d.]+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/([\d.]+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/([\d.]+)`)},
+	{"Safari", regexp.MustCompile(`Version/([\d.]+).*Safari/`)},
+	{"curl", regexp.MustCompile(`^curl/([\d.]+)`)},
+	{"okhttp", regexp.MustCompile(`^okhttp/([\d.]+)`)},
+	{"PostmanRuntime", regexp.MustCompile(`^PostmanRuntime/([\d.]+)`)},
+}
+
+// systems are matched in order, ios and android must come before the desktop systems they mention.
+var systems = []pattern{
+	{"iOS", regexp.MustCompile(`(?:iPhone|CPU) OS ([\d_]+)`)},
+	{"Android", regexp.MustCompile(`Android ?([\d.]*)`)},
+	{"Windows", regexp.MustCompile(`Windows NT ([\d.]+)`)},
+	{"macOS", regexp.MustCompile(`Mac OS X ?([\d_.]*)`)},
+	{"ChromeOS", regexp.MustCompile(`CrOS \S+ ([\d.]+)`)},
+	{"Linux", regexp.MustCompile(`Linux()`)},
+}
+
+// windowsVersions maps the kernel versions to the marketed names.
+var windowsVersions = map[string]string{
+	"10.0": "10",
+	"6.3":  "8.1",
+	"6.2":  "8",
+	"6.1":  "7",
+	"6.0":  "Vista",
+	"5.1":  "XP",
+}
+
+var botPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|facebookexternalhit|headless`)
+
+// Parse reads the user agent string, an empty string parses to an unknown device.
+func Parse(userAgent string) UserAgent {
+	result := UserAgent{DeviceType: Unknown}
+	if userAgent == "" {
+		return result
+	}
+
+	result.Browser, result.BrowserVersion = match(browsers, userAgent)
+	result.OS, result.OSVersion = match(systems, userAgent)
+
+	switch result.OS {
+	case "iOS", "macOS":
+		result.OSVersion = strings.ReplaceAll(result.OSVersion, "_", ".")
+	case "Windows":
+		if name, ok := windowsVersions[result.OSVersion]; ok {
+			result.OSVersion = name
+		}
+	}
+
+	result.DeviceType = deviceType(userAgent, result.OS)
+	return result
+}
+
+// Name describes the device for humans, like "Chrome on Windows".
+func (ua UserAgent) Name() string {
+	switch {
+	case ua.Browser != "" && ua.OS != "":
+		return ua.Browser + " on " + ua.OS
+	case ua.Browser != "":
+		return ua.Browser
+	default:
+		return ua.OS
+	}
+}
+
+// match returns the name and version of the first pattern found in the user agent.
+func match(patterns []pattern, userAgent string) (string, string) {
+	for _, p := range patterns {
+		if m := p.re.FindStringSubmatch(userAgent); m != nil {
+			return p.name, m[1]
+		}
+	}
+	return "", ""
+}
+
+// deviceType guesses the form factor, android tablets are the ones not advertising Mobile.
+func deviceType(userAgent, os string) string {
+	switch {
+	case botPattern.MatchString(userAgent):
+		return Bot
+	case strings.Contains(userAgent, "iPad") || strings.Contains(userAgent, "Tablet"):
+		return Tablet
+	case os == "Android" && !strings.Contains(userAgent, "Mobile"):
+		return Tablet
+	case os == "iOS" || os == "Android" || strings.Contains(userAgent, "Mobile"):
+		return Mobile
+	case os == "Windows" || os == "macOS" || os == "Linux" || os == "ChromeOS":
+		return Desktop
+	default:
+		return Unknown
+	}
+}
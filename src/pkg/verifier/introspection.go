@@ -0,0 +1,90 @@
+package verifier
+
+import (
+	"context"
+	"go-auth-otp-service/src/pkg/client"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// clientTokenRenewal is how long before its expiry the token of the client is renewed.
+const clientTokenRenewal = 30 * time.Second
+
+// IntrospectionVerifier asks the authentication service about every token, as the client it is registered as.
+type IntrospectionVerifier struct {
+	client       *client.Client
+	clientID     string
+	clientSecret string
+
+	mutex       sync.Mutex
+	clientToken *client.ClientToken
+}
+
+// NewIntrospection returns a verifier calling the introspection endpoint with the credentials of the client,
+// the access token of the client is issued on the first call and renewed before it expires.
+func NewIntrospection(c *client.Client, clientID, clientSecret string) *IntrospectionVerifier {
+	return &IntrospectionVerifier{client: c, clientID: clientID, clientSecret: clientSecret}
+}
+
+func (verifier *IntrospectionVerifier) Verify(ctx context.Context, accessToken string) (*Token, error) {
+	if accessToken == "" {
+		return nil, ErrMissingToken
+	}
+
+	introspection, err := verifier.introspect(ctx, accessToken)
+	// the token of the client may have been revoked, it is issued again once
+	if client.IsStatus(err, http.StatusUnauthorized) {
+		verifier.forgetClientToken()
+		introspection, err = verifier.introspect(ctx, accessToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !introspection.Active {
+		return nil, ErrInvalidToken
+	}
+
+	token := &Token{
+		SessionUuid: introspection.SessionUuid,
+		UserUuid:    introspection.UserUuid,
+		Permissions: introspection.Permissions,
+		AuthTime:    introspection.AuthTime,
+		Acr:         introspection.Acr,
+		DPoPJkt:     introspection.DPoPJkt,
+		Actor:       introspection.Actor,
+	}
+	if introspection.ExpiresAt != nil {
+		token.ExpiresAt = *introspection.ExpiresAt
+	}
+	return token, nil
+}
+
+func (verifier *IntrospectionVerifier) introspect(ctx context.Context, accessToken string) (*client.Introspection, error) {
+	clientToken, err := verifier.getClientToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return verifier.client.WithAccessToken(clientToken).Introspect(ctx, accessToken)
+}
+
+// getClientToken returns the access token of the client, issuing a new one when it is about to expire.
+func (verifier *IntrospectionVerifier) getClientToken(ctx context.Context) (string, error) {
+	verifier.mutex.Lock()
+	defer verifier.mutex.Unlock()
+
+	if verifier.clientToken == nil || time.Until(verifier.clientToken.AccessTokenExpiresAt) < clientTokenRenewal {
+		clientToken, err := verifier.client.ClientToken(ctx, verifier.clientID, verifier.clientSecret)
+		if err != nil {
+			return "", err
+		}
+		verifier.clientToken = clientToken
+	}
+	return verifier.clientToken.AccessToken, nil
+}
+
+func (verifier *IntrospectionVerifier) forgetClientToken() {
+	verifier.mutex.Lock()
+	defer verifier.mutex.Unlock()
+	verifier.clientToken = nil
+}
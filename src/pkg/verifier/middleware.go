@@ -0,0 +1,77 @@
+package verifier
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go-auth-otp-service/src/pkg/client"
+	"net/http"
+	"strings"
+)
+
+// Middleware refuses the requests without a valid bearer access token holding every one of the permissions,
+// the handler finds the token with FromContext.
+func Middleware(verifier Verifier, permissions ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, statusCode, err := verify(r, verifier, permissions)
+			if err != nil {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.WriteHeader(statusCode)
+				_ = json.NewEncoder(w).Encode(failure(statusCode, err))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), token)))
+		})
+	}
+}
+
+// Gin is the Middleware of gin handlers, the token is also set as "verified-token" on the gin context.
+func Gin(verifier Verifier, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, statusCode, err := verify(c.Request, verifier, permissions)
+		if err != nil {
+			c.AbortWithStatusJSON(statusCode, failure(statusCode, err))
+			return
+		}
+
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), token))
+		c.Set("verified-token", token)
+		c.Next()
+	}
+}
+
+// verify verifies the bearer token of the request and returns the status code to refuse it with.
+func verify(r *http.Request, verifier Verifier, permissions []string) (*Token, int, error) {
+	accessToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return nil, http.StatusUnauthorized, ErrMissingToken
+	}
+
+	token, err := verifier.Verify(r.Context(), accessToken)
+	if err != nil {
+		if errors.Is(err, ErrMissingToken) || errors.Is(err, ErrInvalidToken) {
+			return nil, http.StatusUnauthorized, err
+		}
+		// the authentication service could not be asked
+		return nil, http.StatusServiceUnavailable, err
+	}
+
+	// proofs of possession are not checked here, so tokens bound to a DPoP key are refused
+	if token.DPoPJkt != "" {
+		return nil, http.StatusUnauthorized, ErrInvalidToken
+	}
+	if !token.HasPermissions(permissions...) {
+		return nil, http.StatusForbidden, ErrPermissionDenied
+	}
+	return token, http.StatusOK, nil
+}
+
+// failure is the envelope of the refused requests, the same the authentication service responds with.
+func failure(statusCode int, err error) *client.Response {
+	message := err.Error()
+	if statusCode == http.StatusServiceUnavailable {
+		message = http.StatusText(statusCode)
+	}
+	return &client.Response{StatusCode: statusCode, Message: message}
+}
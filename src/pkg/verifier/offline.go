@@ -0,0 +1,67 @@
+package verifier
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// claims are the claims of the access tokens, refresh tokens and the tokens of admins and clients
+// are told apart by their owner type.
+type claims struct {
+	OwnerType   string   `json:"owner_type"`
+	Permissions []string `json:"permissions"`
+	Cnf         *struct {
+		JKT string `json:"jkt"`
+	} `json:"cnf"`
+	Act *struct {
+		Subject string `json:"sub"`
+	} `json:"act"`
+	jwt.RegisteredClaims
+}
+
+// OfflineVerifier verifies the signature and the claims of the tokens without calling the authentication service.
+// The tokens of revoked sessions and deactivated users stay valid until they expire.
+type OfflineVerifier struct {
+	secret   []byte
+	issuer   string
+	audience string
+}
+
+// NewOffline returns a verifier of the tokens signed with the JWT_SECRET of the authentication service,
+// the issuer and the audience are its APP_NAME and APP_HOST.
+func NewOffline(secret, issuer, audience string) *OfflineVerifier {
+	return &OfflineVerifier{secret: []byte(secret), issuer: issuer, audience: audience}
+}
+
+func (verifier *OfflineVerifier) Verify(_ context.Context, accessToken string) (*Token, error) {
+	if accessToken == "" {
+		return nil, ErrMissingToken
+	}
+
+	parsed := &claims{}
+	_, err := jwt.ParseWithClaims(accessToken, parsed, func(*jwt.Token) (interface{}, error) {
+		return verifier.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(verifier.issuer),
+		jwt.WithAudience(verifier.audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || parsed.OwnerType != "user" || parsed.ID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	token := &Token{
+		SessionUuid: parsed.ID,
+		UserUuid:    parsed.Subject,
+		Permissions: parsed.Permissions,
+		ExpiresAt:   parsed.ExpiresAt.Time,
+	}
+	if parsed.Cnf != nil {
+		token.DPoPJkt = parsed.Cnf.JKT
+	}
+	if parsed.Act != nil {
+		token.Actor = parsed.Act.Subject
+	}
+	return token, nil
+}
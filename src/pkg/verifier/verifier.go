@@ -0,0 +1,61 @@
+// Package verifier checks the access tokens of users in the services the users call with them.
+// Tokens are verified offline with the signing secret, which is fast but can not tell a revoked session,
+// or through the introspection endpoint of the authentication service, which catches revoked sessions
+// and deactivated users at the cost of a call. Middlewares are provided for net/http and gin.
+package verifier
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	ErrMissingToken     = errors.New("missing access token")
+	ErrInvalidToken     = errors.New("invalid access token")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Token describes a verified access token of a user.
+type Token struct {
+	SessionUuid string
+	UserUuid    string
+	Permissions []string
+	ExpiresAt   time.Time
+	// AuthTime and Acr are only known through introspection.
+	AuthTime *time.Time
+	Acr      string
+	// DPoPJkt is the thumbprint of the key the token is bound to, such tokens are refused by the middlewares.
+	DPoPJkt string
+	// Actor is the admin impersonating the user.
+	Actor string
+}
+
+// HasPermissions reports whether the token holds every one of the permissions.
+func (token *Token) HasPermissions(permissions ...string) bool {
+	for _, permission := range permissions {
+		if !slices.Contains(token.Permissions, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// Verifier verifies the access tokens, invalid tokens fail with ErrInvalidToken.
+type Verifier interface {
+	Verify(ctx context.Context, accessToken string) (*Token, error)
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of the context carrying the token.
+func NewContext(ctx context.Context, token *Token) context.Context {
+	return context.WithValue(ctx, contextKey{}, token)
+}
+
+// FromContext returns the token the middlewares verified the request with.
+func FromContext(ctx context.Context) (*Token, bool) {
+	token, ok := ctx.Value(contextKey{}).(*Token)
+	return token, ok
+}
@@ -4,16 +4,27 @@ import (
 	authentication2 "go-auth-otp-service/src/api/http/controllers/authentication"
 	"go-auth-otp-service/src/database"
 	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
 	"go-auth-otp-service/src/services/authentication"
 )
 
 func ProvideAccessTokenService(accessTokenRepository *repositories.AccessTokenRepository,
+	trustedDeviceRepository *repositories.TrustedDeviceRepository,
 	jwtService *authentication.JwtService,
-	UserRepository *repositories.UserRepository) *authentication.AccessTokenService {
+	UserRepository *repositories.UserRepository,
+	adminRepository *repositories.AdminRepository,
+	clientRepository *repositories.ClientRepository,
+	authorizationService *services.AuthorizationService,
+	auditService *services.AuditService) *authentication.AccessTokenService {
 	return &authentication.AccessTokenService{
-		AccessTokenRepository: accessTokenRepository,
-		JwtService:            jwtService,
-		UserRepository:        UserRepository,
+		AccessTokenRepository:   accessTokenRepository,
+		TrustedDeviceRepository: trustedDeviceRepository,
+		JwtService:              jwtService,
+		UserRepository:          UserRepository,
+		AdminRepository:         adminRepository,
+		ClientRepository:        clientRepository,
+		AuthorizationService:    authorizationService,
+		AuditService:            auditService,
 	}
 }
 
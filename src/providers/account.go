@@ -0,0 +1,27 @@
+package providers
+
+import (
+	"go-auth-otp-service/src/api/http/controllers"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+	"go-auth-otp-service/src/services/authentication"
+)
+
+func ProvideAccountExportService(accessTokenRepository *repositories.AccessTokenRepository, apiKeyRepository *repositories.ApiKeyRepository, auditEventRepository *repositories.AuditEventRepository, authorizationService *services.AuthorizationService) *services.AccountExportService {
+	return &services.AccountExportService{
+		AccessTokenRepository: accessTokenRepository,
+		ApiKeyRepository:      apiKeyRepository,
+		AuditEventRepository:  auditEventRepository,
+		AuthorizationService:  authorizationService,
+	}
+}
+
+func ProvideAccountController(userService *services.UserService, accessTokenService *authentication.AccessTokenService, apiKeyService *authentication.ApiKeyService, trustedDeviceService *authentication.TrustedDeviceService, accountExportService *services.AccountExportService) *controllers.AccountController {
+	return &controllers.AccountController{
+		UserService:          userService,
+		AccessTokenService:   accessTokenService,
+		ApiKeyService:        apiKeyService,
+		TrustedDeviceService: trustedDeviceService,
+		AccountExportService: accountExportService,
+	}
+}
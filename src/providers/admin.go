@@ -0,0 +1,50 @@
+package providers
+
+import (
+	"go-auth-otp-service/src/api/http/controllers/admin"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+	"go-auth-otp-service/src/services/authentication"
+)
+
+func ProvideAdminRepository(db *database.Database) *repositories.AdminRepository {
+	return &repositories.AdminRepository{
+		DatabaseHandler: db,
+	}
+}
+
+func ProvideAdminAuthenticationService(adminRepository *repositories.AdminRepository, otpService *services.OTPService, accessTokenService *authentication.AccessTokenService, jwtService *authentication.JwtService, authorizationService *services.AuthorizationService, auditService *services.AuditService) *authentication.AdminAuthenticationService {
+	return &authentication.AdminAuthenticationService{
+		AdminRepository:      adminRepository,
+		OTPService:           otpService,
+		AccessTokenService:   accessTokenService,
+		JwtService:           jwtService,
+		AuthorizationService: authorizationService,
+		AuditService:         auditService,
+	}
+}
+
+func ProvideAdminAuthenticationController(adminAuthenticationService *authentication.AdminAuthenticationService) *admin.AuthenticationController {
+	return &admin.AuthenticationController{
+		AdminAuthenticationService: adminAuthenticationService,
+	}
+}
+
+func ProvideImpersonationService(adminRepository *repositories.AdminRepository, accessTokenService *authentication.AccessTokenService, jwtService *authentication.JwtService, authorizationService *services.AuthorizationService, auditService *services.AuditService) *authentication.ImpersonationService {
+	return &authentication.ImpersonationService{
+		AdminRepository:      adminRepository,
+		AccessTokenService:   accessTokenService,
+		JwtService:           jwtService,
+		AuthorizationService: authorizationService,
+		AuditService:         auditService,
+	}
+}
+
+func ProvideAdminUserController(userService *services.UserService, accessTokenService *authentication.AccessTokenService, impersonationService *authentication.ImpersonationService) *admin.UserController {
+	return &admin.UserController{
+		UserService:          userService,
+		AccessTokenService:   accessTokenService,
+		ImpersonationService: impersonationService,
+	}
+}
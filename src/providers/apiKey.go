@@ -0,0 +1,26 @@
+package providers
+
+import (
+	authentication_controller "go-auth-otp-service/src/api/http/controllers/authentication"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services/authentication"
+)
+
+func ProvideApiKeyRepository(db *database.Database) *repositories.ApiKeyRepository {
+	return &repositories.ApiKeyRepository{
+		DatabaseHandler: db,
+	}
+}
+
+func ProvideApiKeyService(apiKeyRepository *repositories.ApiKeyRepository) *authentication.ApiKeyService {
+	return &authentication.ApiKeyService{
+		ApiKeyRepository: apiKeyRepository,
+	}
+}
+
+func ProvideApiKeyController(apiKeyService *authentication.ApiKeyService) *authentication_controller.ApiKeyController {
+	return &authentication_controller.ApiKeyController{
+		ApiKeyService: apiKeyService,
+	}
+}
@@ -0,0 +1,33 @@
+package providers
+
+import (
+	"go-auth-otp-service/src/api/http/controllers"
+	"go-auth-otp-service/src/api/http/controllers/admin"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+)
+
+func ProvideAuditEventRepository(db *database.Database) *repositories.AuditEventRepository {
+	return &repositories.AuditEventRepository{
+		DatabaseHandler: db,
+	}
+}
+
+func ProvideAuditService(auditEventRepository *repositories.AuditEventRepository) *services.AuditService {
+	return &services.AuditService{
+		AuditEventRepository: auditEventRepository,
+	}
+}
+
+func ProvideAuditController(auditService *services.AuditService) *controllers.AuditController {
+	return &controllers.AuditController{
+		AuditService: auditService,
+	}
+}
+
+func ProvideAdminAuditController(auditService *services.AuditService) *admin.AuditController {
+	return &admin.AuditController{
+		AuditService: auditService,
+	}
+}
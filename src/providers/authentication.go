@@ -3,6 +3,8 @@ package providers
 import (
 	authentication_controller "go-auth-otp-service/src/api/http/controllers/authentication"
 	"go-auth-otp-service/src/api/http/middlewares"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/repositories"
 	"go-auth-otp-service/src/services"
 	"go-auth-otp-service/src/services/authentication"
 )
@@ -13,12 +15,31 @@ func ProvideUserRegisterController(registerService *authentication.RegisterServi
 	}
 }
 
-func ProvideRegisterService(userService *services.UserService, otpService *services.OTPService, jwtService *authentication.JwtService, accessTokenService *authentication.AccessTokenService) *authentication.RegisterService {
+func ProvideRegisterService(userService *services.UserService, otpService *services.OTPService, jwtService *authentication.JwtService, accessTokenService *authentication.AccessTokenService, authorizationService *services.AuthorizationService, auditService *services.AuditService, deviceService *authentication.DeviceService, trustedDeviceService *authentication.TrustedDeviceService) *authentication.RegisterService {
 	return &authentication.RegisterService{
-		UserService:        userService,
-		OTPService:         otpService,
-		AccessTokenService: accessTokenService,
-		JwtService:         jwtService,
+		UserService:          userService,
+		OTPService:           otpService,
+		AccessTokenService:   accessTokenService,
+		JwtService:           jwtService,
+		AuthorizationService: authorizationService,
+		AuditService:         auditService,
+		DeviceService:        deviceService,
+		TrustedDeviceService: trustedDeviceService,
+	}
+}
+
+func ProvideDeviceService(accessTokenRepository *repositories.AccessTokenRepository, trustedDeviceRepository *repositories.TrustedDeviceRepository, accessTokenService *authentication.AccessTokenService, auditService *services.AuditService) *authentication.DeviceService {
+	return &authentication.DeviceService{
+		AccessTokenRepository:   accessTokenRepository,
+		TrustedDeviceRepository: trustedDeviceRepository,
+		AccessTokenService:      accessTokenService,
+		AuditService:            auditService,
+	}
+}
+
+func ProvideDeviceController(deviceService *authentication.DeviceService) *authentication_controller.DeviceController {
+	return &authentication_controller.DeviceController{
+		DeviceService: deviceService,
 	}
 }
 
@@ -26,8 +47,70 @@ func ProvideJwtService() *authentication.JwtService {
 	return &authentication.JwtService{}
 }
 
-func ProvideAuthenticationMiddleware(accessTokenService *authentication.AccessTokenService) *middlewares.AuthenticationMiddleware {
+func ProvideAuthenticationMiddleware(accessTokenService *authentication.AccessTokenService, apiKeyService *authentication.ApiKeyService, authorizationService *services.AuthorizationService, dpopService *authentication.DPoPService, auditService *services.AuditService) *middlewares.AuthenticationMiddleware {
 	return &middlewares.AuthenticationMiddleware{
-		AccessTokenService: accessTokenService,
+		AccessTokenService:   accessTokenService,
+		ApiKeyService:        apiKeyService,
+		AuthorizationService: authorizationService,
+		DPoPService:          dpopService,
+		AuditService:         auditService,
+	}
+}
+
+func ProvideDPoPService() *authentication.DPoPService {
+	return &authentication.DPoPService{}
+}
+
+func ProvideTrustedDeviceRepository(db *database.Database) *repositories.TrustedDeviceRepository {
+	return &repositories.TrustedDeviceRepository{
+		DatabaseHandler: db,
+	}
+}
+
+func ProvideTrustedDeviceService(trustedDeviceRepository *repositories.TrustedDeviceRepository, userService *services.UserService, accessTokenService *authentication.AccessTokenService, jwtService *authentication.JwtService, authorizationService *services.AuthorizationService, auditService *services.AuditService) *authentication.TrustedDeviceService {
+	return &authentication.TrustedDeviceService{
+		TrustedDeviceRepository: trustedDeviceRepository,
+		UserService:             userService,
+		AccessTokenService:      accessTokenService,
+		JwtService:              jwtService,
+		AuthorizationService:    authorizationService,
+		AuditService:            auditService,
+	}
+}
+
+func ProvideTrustedDeviceController(trustedDeviceService *authentication.TrustedDeviceService) *authentication_controller.TrustedDeviceController {
+	return &authentication_controller.TrustedDeviceController{
+		TrustedDeviceService: trustedDeviceService,
+	}
+}
+
+func ProvideStepUpService(userService *services.UserService, adminRepository *repositories.AdminRepository, otpService *services.OTPService, accessTokenRepository *repositories.AccessTokenRepository, auditService *services.AuditService) *authentication.StepUpService {
+	return &authentication.StepUpService{
+		UserService:           userService,
+		AdminRepository:       adminRepository,
+		OTPService:            otpService,
+		AccessTokenRepository: accessTokenRepository,
+		AuditService:          auditService,
+	}
+}
+
+func ProvideStepUpController(stepUpService *authentication.StepUpService) *authentication_controller.StepUpController {
+	return &authentication_controller.StepUpController{
+		StepUpService: stepUpService,
+	}
+}
+
+func ProvideIntrospectionService(accessTokenService *authentication.AccessTokenService, userService *services.UserService, authorizationService *services.AuthorizationService, auditService *services.AuditService) *authentication.IntrospectionService {
+	return &authentication.IntrospectionService{
+		AccessTokenService:   accessTokenService,
+		UserService:          userService,
+		AuthorizationService: authorizationService,
+		AuditService:         auditService,
+	}
+}
+
+func ProvideIntrospectionController(introspectionService *authentication.IntrospectionService) *authentication_controller.IntrospectionController {
+	return &authentication_controller.IntrospectionController{
+		IntrospectionService: introspectionService,
 	}
 }
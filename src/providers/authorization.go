@@ -0,0 +1,34 @@
+package providers
+
+import (
+	"go-auth-otp-service/src/api/http/controllers"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+)
+
+func ProvideRoleRepository(db *database.Database) *repositories.RoleRepository {
+	return &repositories.RoleRepository{
+		DatabaseHandler: db,
+	}
+}
+
+func ProvidePermissionRepository(db *database.Database) *repositories.PermissionRepository {
+	return &repositories.PermissionRepository{
+		DatabaseHandler: db,
+	}
+}
+
+func ProvideAuthorizationService(roleRepository *repositories.RoleRepository, permissionRepository *repositories.PermissionRepository) *services.AuthorizationService {
+	return &services.AuthorizationService{
+		RoleRepository:       roleRepository,
+		PermissionRepository: permissionRepository,
+	}
+}
+
+func ProvideAuthorizationController(authorizationService *services.AuthorizationService, userService *services.UserService) *controllers.AuthorizationController {
+	return &controllers.AuthorizationController{
+		AuthorizationService: authorizationService,
+		UserService:          userService,
+	}
+}
@@ -0,0 +1,30 @@
+package providers
+
+import (
+	authentication_controller "go-auth-otp-service/src/api/http/controllers/authentication"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+	"go-auth-otp-service/src/services/authentication"
+)
+
+func ProvideClientRepository(db *database.Database) *repositories.ClientRepository {
+	return &repositories.ClientRepository{
+		DatabaseHandler: db,
+	}
+}
+
+func ProvideClientCredentialsService(clientRepository *repositories.ClientRepository, accessTokenService *authentication.AccessTokenService, jwtService *authentication.JwtService, auditService *services.AuditService) *authentication.ClientCredentialsService {
+	return &authentication.ClientCredentialsService{
+		ClientRepository:   clientRepository,
+		AccessTokenService: accessTokenService,
+		JwtService:         jwtService,
+		AuditService:       auditService,
+	}
+}
+
+func ProvideClientCredentialsController(clientCredentialsService *authentication.ClientCredentialsService) *authentication_controller.ClientCredentialsController {
+	return &authentication_controller.ClientCredentialsController{
+		ClientCredentialsService: clientCredentialsService,
+	}
+}
@@ -0,0 +1,9 @@
+package providers
+
+import (
+	"go-auth-otp-service/src/api/http/controllers"
+)
+
+func ProvideOpenAPIController() *controllers.OpenAPIController {
+	return &controllers.OpenAPIController{}
+}
@@ -0,0 +1,24 @@
+package providers
+
+import (
+	"go-auth-otp-service/src/api/grpc/interceptors"
+	"go-auth-otp-service/src/api/grpc/servers"
+	"go-auth-otp-service/src/services"
+	"go-auth-otp-service/src/services/authentication"
+)
+
+func ProvideAuthServer(registerService *authentication.RegisterService, accessTokenService *authentication.AccessTokenService, userService *services.UserService, introspectionService *authentication.IntrospectionService) *servers.AuthServer {
+	return &servers.AuthServer{
+		RegisterService:      registerService,
+		AccessTokenService:   accessTokenService,
+		UserService:          userService,
+		IntrospectionService: introspectionService,
+	}
+}
+
+func ProvideAuthenticationInterceptor(accessTokenService *authentication.AccessTokenService, auditService *services.AuditService) *interceptors.AuthenticationInterceptor {
+	return &interceptors.AuthenticationInterceptor{
+		AccessTokenService: accessTokenService,
+		AuditService:       auditService,
+	}
+}
@@ -0,0 +1,21 @@
+package providers
+
+import (
+	"go-auth-otp-service/src/api/http/controllers"
+	"go-auth-otp-service/src/cache"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/services"
+)
+
+func ProvideHealthService(db *database.Database, cache *cache.Cache) *services.HealthService {
+	return &services.HealthService{
+		Database: db,
+		Cache:    cache,
+	}
+}
+
+func ProvideHealthController(healthService *services.HealthService) *controllers.HealthController {
+	return &controllers.HealthController{
+		HealthService: healthService,
+	}
+}
@@ -0,0 +1,19 @@
+package providers
+
+import (
+	"go-auth-otp-service/src/jobs"
+	"go-auth-otp-service/src/services"
+	"go-auth-otp-service/src/services/authentication"
+)
+
+func ProvideAnonymizeUsersJob(userService *services.UserService) *jobs.AnonymizeUsersJob {
+	return &jobs.AnonymizeUsersJob{
+		UserService: userService,
+	}
+}
+
+func ProvidePruneTokensJob(accessTokenService *authentication.AccessTokenService) *jobs.PruneTokensJob {
+	return &jobs.PruneTokensJob{
+		AccessTokenService: accessTokenService,
+	}
+}
@@ -13,9 +13,10 @@ func ProvideUserRepository(db *database.Database) *repositories.UserRepository {
 	}
 }
 
-func ProvideUserService(userRepository *repositories.UserRepository) *services.UserService {
+func ProvideUserService(userRepository *repositories.UserRepository, auditService *services.AuditService) *services.UserService {
 	return &services.UserService{
 		UserRepository: userRepository,
+		AuditService:   auditService,
 	}
 }
 
@@ -24,3 +25,16 @@ func ProvideUserController(userService *services.UserService) *controllers.UserC
 		UserService: userService,
 	}
 }
+
+func ProvideProfileImageService(userRepository *repositories.UserRepository) *services.ProfileImageService {
+	return &services.ProfileImageService{
+		UserRepository: userRepository,
+	}
+}
+
+func ProvideProfileImageController(userService *services.UserService, profileImageService *services.ProfileImageService) *controllers.ProfileImageController {
+	return &controllers.ProfileImageController{
+		UserService:         userService,
+		ProfileImageService: profileImageService,
+	}
+}
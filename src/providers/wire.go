@@ -5,20 +5,59 @@ package providers
 
 import (
 	"github.com/google/wire"
+	"go-auth-otp-service/src/api/grpc/interceptors"
+	"go-auth-otp-service/src/api/grpc/servers"
 	"go-auth-otp-service/src/api/http/controllers"
+	"go-auth-otp-service/src/api/http/controllers/admin"
 	authentication2 "go-auth-otp-service/src/api/http/controllers/authentication"
 	"go-auth-otp-service/src/api/http/middlewares"
+	"go-auth-otp-service/src/cache"
 	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/jobs"
 )
 
 type (
 	AuthenticationContainer struct {
-		UserRegisterController   *authentication2.RegisterController
-		AuthenticationMiddleware *middlewares.AuthenticationMiddleware
-		AccessTokenController    *authentication2.AccessTokenController
+		UserRegisterController      *authentication2.RegisterController
+		AuthenticationMiddleware    *middlewares.AuthenticationMiddleware
+		AccessTokenController       *authentication2.AccessTokenController
+		ClientCredentialsController *authentication2.ClientCredentialsController
+		ApiKeyController            *authentication2.ApiKeyController
+		DeviceController            *authentication2.DeviceController
+		TrustedDeviceController     *authentication2.TrustedDeviceController
+		StepUpController            *authentication2.StepUpController
+		IntrospectionController     *authentication2.IntrospectionController
 	}
 	UserContainer struct {
-		UserController *controllers.UserController
+		UserController         *controllers.UserController
+		ProfileImageController *controllers.ProfileImageController
+		AccountController      *controllers.AccountController
+		AuditController        *controllers.AuditController
+	}
+	AuthorizationContainer struct {
+		AuthorizationController *controllers.AuthorizationController
+	}
+	JobsContainer struct {
+		AnonymizeUsersJob *jobs.AnonymizeUsersJob
+		PruneTokensJob    *jobs.PruneTokensJob
+	}
+	StorageContainer struct {
+		StorageController *controllers.StorageController
+	}
+	DocsContainer struct {
+		OpenAPIController *controllers.OpenAPIController
+	}
+	HealthContainer struct {
+		HealthController *controllers.HealthController
+	}
+	AdminContainer struct {
+		AdminAuthenticationController *admin.AuthenticationController
+		AdminUserController           *admin.UserController
+		AdminAuditController          *admin.AuditController
+	}
+	GrpcContainer struct {
+		AuthServer                *servers.AuthServer
+		AuthenticationInterceptor *interceptors.AuthenticationInterceptor
 	}
 )
 
@@ -28,15 +67,37 @@ func GetAuthenticationContainer() *AuthenticationContainer {
 		database.GetInstance,
 		ProvideUserRepository,
 		ProvideAccessTokenRepository,
+		ProvideClientRepository,
+		ProvideApiKeyRepository,
+		ProvideAdminRepository,
+		ProvideRoleRepository,
+		ProvidePermissionRepository,
+		ProvideAuditEventRepository,
+		ProvideTrustedDeviceRepository,
 		// Services
+		ProvideAuditService,
 		ProvideRegisterService,
 		ProvideUserService,
 		ProvideOTPService,
 		ProvideJwtService,
 		ProvideAccessTokenService,
+		ProvideClientCredentialsService,
+		ProvideApiKeyService,
+		ProvideAuthorizationService,
+		ProvideDeviceService,
+		ProvideTrustedDeviceService,
+		ProvideStepUpService,
+		ProvideDPoPService,
+		ProvideIntrospectionService,
 		// Controllers
 		ProvideUserRegisterController,
 		ProvideUserAccessTokenController,
+		ProvideClientCredentialsController,
+		ProvideApiKeyController,
+		ProvideDeviceController,
+		ProvideTrustedDeviceController,
+		ProvideStepUpController,
+		ProvideIntrospectionController,
 		// Middlewares
 		ProvideAuthenticationMiddleware,
 
@@ -50,11 +111,168 @@ func GetUserContainer() *UserContainer {
 		// Repositories
 		database.GetInstance,
 		ProvideUserRepository,
+		ProvideAccessTokenRepository,
+		ProvideApiKeyRepository,
+		ProvideAdminRepository,
+		ProvideClientRepository,
+		ProvideRoleRepository,
+		ProvidePermissionRepository,
+		ProvideAuditEventRepository,
+		ProvideTrustedDeviceRepository,
 		// Services
+		ProvideAuditService,
 		ProvideUserService,
+		ProvideProfileImageService,
+		ProvideJwtService,
+		ProvideAuthorizationService,
+		ProvideAccessTokenService,
+		ProvideApiKeyService,
+		ProvideTrustedDeviceService,
+		ProvideAccountExportService,
 		// Controllers
 		ProvideUserController,
+		ProvideProfileImageController,
+		ProvideAccountController,
+		ProvideAuditController,
 		wire.Struct(new(UserContainer), "*"),
 	)
 	return nil
 }
+
+func GetJobsContainer() *JobsContainer {
+	wire.Build(
+		// Repositories
+		database.GetInstance,
+		ProvideUserRepository,
+		ProvideAuditEventRepository,
+		ProvideAccessTokenRepository,
+		ProvideTrustedDeviceRepository,
+		ProvideAdminRepository,
+		ProvideClientRepository,
+		ProvideRoleRepository,
+		ProvidePermissionRepository,
+		// Services
+		ProvideAuditService,
+		ProvideUserService,
+		ProvideJwtService,
+		ProvideAuthorizationService,
+		ProvideAccessTokenService,
+		// Jobs
+		ProvideAnonymizeUsersJob,
+		ProvidePruneTokensJob,
+		wire.Struct(new(JobsContainer), "*"),
+	)
+	return nil
+}
+
+func GetStorageContainer() *StorageContainer {
+	wire.Build(
+		// Controllers
+		ProvideStorageController,
+		wire.Struct(new(StorageContainer), "*"),
+	)
+	return nil
+}
+
+func GetDocsContainer() *DocsContainer {
+	wire.Build(
+		// Controllers
+		ProvideOpenAPIController,
+		wire.Struct(new(DocsContainer), "*"),
+	)
+	return nil
+}
+
+func GetHealthContainer() *HealthContainer {
+	wire.Build(
+		database.GetInstance,
+		cache.GetInstance,
+		// Services
+		ProvideHealthService,
+		// Controllers
+		ProvideHealthController,
+		wire.Struct(new(HealthContainer), "*"),
+	)
+	return nil
+}
+
+func GetAuthorizationContainer() *AuthorizationContainer {
+	wire.Build(
+		// Repositories
+		database.GetInstance,
+		ProvideUserRepository,
+		ProvideRoleRepository,
+		ProvidePermissionRepository,
+		ProvideAuditEventRepository,
+		// Services
+		ProvideAuditService,
+		ProvideUserService,
+		ProvideAuthorizationService,
+		// Controllers
+		ProvideAuthorizationController,
+		wire.Struct(new(AuthorizationContainer), "*"),
+	)
+	return nil
+}
+
+func GetAdminContainer() *AdminContainer {
+	wire.Build(
+		// Repositories
+		database.GetInstance,
+		ProvideUserRepository,
+		ProvideAdminRepository,
+		ProvideAccessTokenRepository,
+		ProvideTrustedDeviceRepository,
+		ProvideClientRepository,
+		ProvideRoleRepository,
+		ProvidePermissionRepository,
+		ProvideAuditEventRepository,
+		// Services
+		ProvideAuditService,
+		ProvideOTPService,
+		ProvideJwtService,
+		ProvideAuthorizationService,
+		ProvideAccessTokenService,
+		ProvideAdminAuthenticationService,
+		ProvideUserService,
+		ProvideImpersonationService,
+		// Controllers
+		ProvideAdminAuthenticationController,
+		ProvideAdminUserController,
+		ProvideAdminAuditController,
+		wire.Struct(new(AdminContainer), "*"),
+	)
+	return nil
+}
+
+func GetGrpcContainer() *GrpcContainer {
+	wire.Build(
+		// Repositories
+		database.GetInstance,
+		ProvideUserRepository,
+		ProvideAccessTokenRepository,
+		ProvideClientRepository,
+		ProvideAdminRepository,
+		ProvideRoleRepository,
+		ProvidePermissionRepository,
+		ProvideAuditEventRepository,
+		ProvideTrustedDeviceRepository,
+		// Services
+		ProvideAuditService,
+		ProvideRegisterService,
+		ProvideUserService,
+		ProvideOTPService,
+		ProvideJwtService,
+		ProvideAccessTokenService,
+		ProvideAuthorizationService,
+		ProvideDeviceService,
+		ProvideTrustedDeviceService,
+		ProvideIntrospectionService,
+		// Servers
+		ProvideAuthServer,
+		// Interceptors
+		ProvideAuthenticationInterceptor,
+		wire.Struct(new(GrpcContainer), "*"),
+	)
+	return nil
+}
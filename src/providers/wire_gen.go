@@ -7,10 +7,15 @@
 package providers
 
 import (
+	"go-auth-otp-service/src/api/grpc/interceptors"
+	"go-auth-otp-service/src/api/grpc/servers"
 	"go-auth-otp-service/src/api/http/controllers"
+	"go-auth-otp-service/src/api/http/controllers/admin"
 	"go-auth-otp-service/src/api/http/controllers/authentication"
 	"go-auth-otp-service/src/api/http/middlewares"
+	"go-auth-otp-service/src/cache"
 	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/jobs"
 )
 
 // Injectors from wire.go:
@@ -18,19 +23,47 @@ import (
 func GetAuthenticationContainer() *AuthenticationContainer {
 	databaseDatabase := database.GetInstance()
 	userRepository := ProvideUserRepository(databaseDatabase)
-	userService := ProvideUserService(userRepository)
+	auditEventRepository := ProvideAuditEventRepository(databaseDatabase)
+	auditService := ProvideAuditService(auditEventRepository)
+	userService := ProvideUserService(userRepository, auditService)
 	otpService := ProvideOTPService()
 	jwtService := ProvideJwtService()
 	accessTokenRepository := ProvideAccessTokenRepository(databaseDatabase)
-	accessTokenService := ProvideAccessTokenService(accessTokenRepository, jwtService, userRepository)
-	registerService := ProvideRegisterService(userService, otpService, jwtService, accessTokenService)
+	roleRepository := ProvideRoleRepository(databaseDatabase)
+	permissionRepository := ProvidePermissionRepository(databaseDatabase)
+	authorizationService := ProvideAuthorizationService(roleRepository, permissionRepository)
+	adminRepository := ProvideAdminRepository(databaseDatabase)
+	clientRepository := ProvideClientRepository(databaseDatabase)
+	trustedDeviceRepository := ProvideTrustedDeviceRepository(databaseDatabase)
+	accessTokenService := ProvideAccessTokenService(accessTokenRepository, trustedDeviceRepository, jwtService, userRepository, adminRepository, clientRepository, authorizationService, auditService)
+	deviceService := ProvideDeviceService(accessTokenRepository, trustedDeviceRepository, accessTokenService, auditService)
+	trustedDeviceService := ProvideTrustedDeviceService(trustedDeviceRepository, userService, accessTokenService, jwtService, authorizationService, auditService)
+	registerService := ProvideRegisterService(userService, otpService, jwtService, accessTokenService, authorizationService, auditService, deviceService, trustedDeviceService)
 	registerController := ProvideUserRegisterController(registerService)
-	authenticationMiddleware := ProvideAuthenticationMiddleware(accessTokenService)
+	apiKeyRepository := ProvideApiKeyRepository(databaseDatabase)
+	apiKeyService := ProvideApiKeyService(apiKeyRepository)
+	dPoPService := ProvideDPoPService()
+	authenticationMiddleware := ProvideAuthenticationMiddleware(accessTokenService, apiKeyService, authorizationService, dPoPService, auditService)
 	accessTokenController := ProvideUserAccessTokenController(accessTokenService)
+	clientCredentialsService := ProvideClientCredentialsService(clientRepository, accessTokenService, jwtService, auditService)
+	clientCredentialsController := ProvideClientCredentialsController(clientCredentialsService)
+	apiKeyController := ProvideApiKeyController(apiKeyService)
+	deviceController := ProvideDeviceController(deviceService)
+	trustedDeviceController := ProvideTrustedDeviceController(trustedDeviceService)
+	stepUpService := ProvideStepUpService(userService, adminRepository, otpService, accessTokenRepository, auditService)
+	stepUpController := ProvideStepUpController(stepUpService)
+	introspectionService := ProvideIntrospectionService(accessTokenService, userService, authorizationService, auditService)
+	introspectionController := ProvideIntrospectionController(introspectionService)
 	authenticationContainer := &AuthenticationContainer{
-		UserRegisterController:   registerController,
-		AuthenticationMiddleware: authenticationMiddleware,
-		AccessTokenController:    accessTokenController,
+		UserRegisterController:      registerController,
+		AuthenticationMiddleware:    authenticationMiddleware,
+		AccessTokenController:       accessTokenController,
+		ClientCredentialsController: clientCredentialsController,
+		ApiKeyController:            apiKeyController,
+		DeviceController:            deviceController,
+		TrustedDeviceController:     trustedDeviceController,
+		StepUpController:            stepUpController,
+		IntrospectionController:     introspectionController,
 	}
 	return authenticationContainer
 }
@@ -38,23 +71,204 @@ func GetAuthenticationContainer() *AuthenticationContainer {
 func GetUserContainer() *UserContainer {
 	databaseDatabase := database.GetInstance()
 	userRepository := ProvideUserRepository(databaseDatabase)
-	userService := ProvideUserService(userRepository)
+	auditEventRepository := ProvideAuditEventRepository(databaseDatabase)
+	auditService := ProvideAuditService(auditEventRepository)
+	userService := ProvideUserService(userRepository, auditService)
 	userController := ProvideUserController(userService)
+	profileImageService := ProvideProfileImageService(userRepository)
+	profileImageController := ProvideProfileImageController(userService, profileImageService)
+	accessTokenRepository := ProvideAccessTokenRepository(databaseDatabase)
+	jwtService := ProvideJwtService()
+	adminRepository := ProvideAdminRepository(databaseDatabase)
+	clientRepository := ProvideClientRepository(databaseDatabase)
+	roleRepository := ProvideRoleRepository(databaseDatabase)
+	permissionRepository := ProvidePermissionRepository(databaseDatabase)
+	authorizationService := ProvideAuthorizationService(roleRepository, permissionRepository)
+	trustedDeviceRepository := ProvideTrustedDeviceRepository(databaseDatabase)
+	accessTokenService := ProvideAccessTokenService(accessTokenRepository, trustedDeviceRepository, jwtService, userRepository, adminRepository, clientRepository, authorizationService, auditService)
+	apiKeyRepository := ProvideApiKeyRepository(databaseDatabase)
+	apiKeyService := ProvideApiKeyService(apiKeyRepository)
+	trustedDeviceService := ProvideTrustedDeviceService(trustedDeviceRepository, userService, accessTokenService, jwtService, authorizationService, auditService)
+	accountExportService := ProvideAccountExportService(accessTokenRepository, apiKeyRepository, auditEventRepository, authorizationService)
+	accountController := ProvideAccountController(userService, accessTokenService, apiKeyService, trustedDeviceService, accountExportService)
+	auditController := ProvideAuditController(auditService)
 	userContainer := &UserContainer{
-		UserController: userController,
+		UserController:         userController,
+		ProfileImageController: profileImageController,
+		AccountController:      accountController,
+		AuditController:        auditController,
 	}
 	return userContainer
 }
 
+func GetJobsContainer() *JobsContainer {
+	databaseDatabase := database.GetInstance()
+	userRepository := ProvideUserRepository(databaseDatabase)
+	auditEventRepository := ProvideAuditEventRepository(databaseDatabase)
+	auditService := ProvideAuditService(auditEventRepository)
+	userService := ProvideUserService(userRepository, auditService)
+	anonymizeUsersJob := ProvideAnonymizeUsersJob(userService)
+	accessTokenRepository := ProvideAccessTokenRepository(databaseDatabase)
+	jwtService := ProvideJwtService()
+	adminRepository := ProvideAdminRepository(databaseDatabase)
+	clientRepository := ProvideClientRepository(databaseDatabase)
+	roleRepository := ProvideRoleRepository(databaseDatabase)
+	permissionRepository := ProvidePermissionRepository(databaseDatabase)
+	authorizationService := ProvideAuthorizationService(roleRepository, permissionRepository)
+	trustedDeviceRepository := ProvideTrustedDeviceRepository(databaseDatabase)
+	accessTokenService := ProvideAccessTokenService(accessTokenRepository, trustedDeviceRepository, jwtService, userRepository, adminRepository, clientRepository, authorizationService, auditService)
+	pruneTokensJob := ProvidePruneTokensJob(accessTokenService)
+	jobsContainer := &JobsContainer{
+		AnonymizeUsersJob: anonymizeUsersJob,
+		PruneTokensJob:    pruneTokensJob,
+	}
+	return jobsContainer
+}
+
+func GetStorageContainer() *StorageContainer {
+	storageController := ProvideStorageController()
+	storageContainer := &StorageContainer{
+		StorageController: storageController,
+	}
+	return storageContainer
+}
+
+func GetDocsContainer() *DocsContainer {
+	openAPIController := ProvideOpenAPIController()
+	docsContainer := &DocsContainer{
+		OpenAPIController: openAPIController,
+	}
+	return docsContainer
+}
+
+func GetHealthContainer() *HealthContainer {
+	databaseDatabase := database.GetInstance()
+	cacheCache := cache.GetInstance()
+	healthService := ProvideHealthService(databaseDatabase, cacheCache)
+	healthController := ProvideHealthController(healthService)
+	healthContainer := &HealthContainer{
+		HealthController: healthController,
+	}
+	return healthContainer
+}
+
+func GetAuthorizationContainer() *AuthorizationContainer {
+	databaseDatabase := database.GetInstance()
+	roleRepository := ProvideRoleRepository(databaseDatabase)
+	permissionRepository := ProvidePermissionRepository(databaseDatabase)
+	authorizationService := ProvideAuthorizationService(roleRepository, permissionRepository)
+	userRepository := ProvideUserRepository(databaseDatabase)
+	auditEventRepository := ProvideAuditEventRepository(databaseDatabase)
+	auditService := ProvideAuditService(auditEventRepository)
+	userService := ProvideUserService(userRepository, auditService)
+	authorizationController := ProvideAuthorizationController(authorizationService, userService)
+	authorizationContainer := &AuthorizationContainer{
+		AuthorizationController: authorizationController,
+	}
+	return authorizationContainer
+}
+
+func GetAdminContainer() *AdminContainer {
+	databaseDatabase := database.GetInstance()
+	adminRepository := ProvideAdminRepository(databaseDatabase)
+	otpService := ProvideOTPService()
+	accessTokenRepository := ProvideAccessTokenRepository(databaseDatabase)
+	jwtService := ProvideJwtService()
+	userRepository := ProvideUserRepository(databaseDatabase)
+	roleRepository := ProvideRoleRepository(databaseDatabase)
+	permissionRepository := ProvidePermissionRepository(databaseDatabase)
+	authorizationService := ProvideAuthorizationService(roleRepository, permissionRepository)
+	clientRepository := ProvideClientRepository(databaseDatabase)
+	auditEventRepository := ProvideAuditEventRepository(databaseDatabase)
+	auditService := ProvideAuditService(auditEventRepository)
+	trustedDeviceRepository := ProvideTrustedDeviceRepository(databaseDatabase)
+	accessTokenService := ProvideAccessTokenService(accessTokenRepository, trustedDeviceRepository, jwtService, userRepository, adminRepository, clientRepository, authorizationService, auditService)
+	adminAuthenticationService := ProvideAdminAuthenticationService(adminRepository, otpService, accessTokenService, jwtService, authorizationService, auditService)
+	authenticationController := ProvideAdminAuthenticationController(adminAuthenticationService)
+	userService := ProvideUserService(userRepository, auditService)
+	impersonationService := ProvideImpersonationService(adminRepository, accessTokenService, jwtService, authorizationService, auditService)
+	userController := ProvideAdminUserController(userService, accessTokenService, impersonationService)
+	auditController := ProvideAdminAuditController(auditService)
+	adminContainer := &AdminContainer{
+		AdminAuthenticationController: authenticationController,
+		AdminUserController:           userController,
+		AdminAuditController:          auditController,
+	}
+	return adminContainer
+}
+
+func GetGrpcContainer() *GrpcContainer {
+	databaseDatabase := database.GetInstance()
+	userRepository := ProvideUserRepository(databaseDatabase)
+	auditEventRepository := ProvideAuditEventRepository(databaseDatabase)
+	auditService := ProvideAuditService(auditEventRepository)
+	userService := ProvideUserService(userRepository, auditService)
+	otpService := ProvideOTPService()
+	jwtService := ProvideJwtService()
+	accessTokenRepository := ProvideAccessTokenRepository(databaseDatabase)
+	roleRepository := ProvideRoleRepository(databaseDatabase)
+	permissionRepository := ProvidePermissionRepository(databaseDatabase)
+	authorizationService := ProvideAuthorizationService(roleRepository, permissionRepository)
+	adminRepository := ProvideAdminRepository(databaseDatabase)
+	clientRepository := ProvideClientRepository(databaseDatabase)
+	trustedDeviceRepository := ProvideTrustedDeviceRepository(databaseDatabase)
+	accessTokenService := ProvideAccessTokenService(accessTokenRepository, trustedDeviceRepository, jwtService, userRepository, adminRepository, clientRepository, authorizationService, auditService)
+	deviceService := ProvideDeviceService(accessTokenRepository, trustedDeviceRepository, accessTokenService, auditService)
+	trustedDeviceService := ProvideTrustedDeviceService(trustedDeviceRepository, userService, accessTokenService, jwtService, authorizationService, auditService)
+	registerService := ProvideRegisterService(userService, otpService, jwtService, accessTokenService, authorizationService, auditService, deviceService, trustedDeviceService)
+	introspectionService := ProvideIntrospectionService(accessTokenService, userService, authorizationService, auditService)
+	authServer := ProvideAuthServer(registerService, accessTokenService, userService, introspectionService)
+	authenticationInterceptor := ProvideAuthenticationInterceptor(accessTokenService, auditService)
+	grpcContainer := &GrpcContainer{
+		AuthServer:                authServer,
+		AuthenticationInterceptor: authenticationInterceptor,
+	}
+	return grpcContainer
+}
+
 // wire.go:
 
 type (
 	AuthenticationContainer struct {
-		UserRegisterController   *authentication.RegisterController
-		AuthenticationMiddleware *middlewares.AuthenticationMiddleware
-		AccessTokenController    *authentication.AccessTokenController
+		UserRegisterController      *authentication.RegisterController
+		AuthenticationMiddleware    *middlewares.AuthenticationMiddleware
+		AccessTokenController       *authentication.AccessTokenController
+		ClientCredentialsController *authentication.ClientCredentialsController
+		ApiKeyController            *authentication.ApiKeyController
+		DeviceController            *authentication.DeviceController
+		TrustedDeviceController     *authentication.TrustedDeviceController
+		StepUpController            *authentication.StepUpController
+		IntrospectionController     *authentication.IntrospectionController
 	}
 	UserContainer struct {
-		UserController *controllers.UserController
+		UserController         *controllers.UserController
+		ProfileImageController *controllers.ProfileImageController
+		AccountController      *controllers.AccountController
+		AuditController        *controllers.AuditController
+	}
+	AuthorizationContainer struct {
+		AuthorizationController *controllers.AuthorizationController
+	}
+	JobsContainer struct {
+		AnonymizeUsersJob *jobs.AnonymizeUsersJob
+		PruneTokensJob    *jobs.PruneTokensJob
+	}
+	StorageContainer struct {
+		StorageController *controllers.StorageController
+	}
+	DocsContainer struct {
+		OpenAPIController *controllers.OpenAPIController
+	}
+	HealthContainer struct {
+		HealthController *controllers.HealthController
+	}
+	AdminContainer struct {
+		AdminAuthenticationController *admin.AuthenticationController
+		AdminUserController           *admin.UserController
+		AdminAuditController          *admin.AuditController
+	}
+	GrpcContainer struct {
+		AuthServer                *servers.AuthServer
+		AuthenticationInterceptor *interceptors.AuthenticationInterceptor
 	}
 )
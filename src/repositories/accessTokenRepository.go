@@ -9,19 +9,28 @@ import (
 	"go-auth-otp-service/src/hash"
 	"go-auth-otp-service/src/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
 type IAccessTokenRepository interface {
 	GetAll(ownerID uint, ownerType string) ([]*models.AccessTokenModel, error)
+	GetAllWithTrashed(ownerID uint, ownerType string) ([]*models.AccessTokenModel, error)
+	GetUnexpired(ownerID uint, ownerType string) ([]*models.AccessTokenModel, error)
 	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
 	GetActiveTokens(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
 	GetByUuid(accessTokenUuid *uuid.UUID) (*models.AccessTokenModel, error)
+	GetByID(id uint) (*models.AccessTokenModel, error)
+	CountDevices(ownerID uint, ownerType string) (int64, error)
+	HasDevice(ownerID uint, ownerType, ip, userAgent string) (bool, error)
+	GetDevices(ownerID uint, ownerType string) ([]*models.KnownDeviceModel, error)
 	Create(accessToken *models.AccessTokenModel) (*models.AccessTokenModel, error)
 	UpdateLastUsedAt(accessToken *models.AccessTokenModel, timestamp time.Time) (*models.AccessTokenModel, error)
+	UpdateAuthTime(accessToken *models.AccessTokenModel, authTime time.Time, acr string) (*models.AccessTokenModel, error)
 	RefreshAccessTokens(accessToken *models.AccessTokenModel, uuid uuid.UUID, newAccessToken, newRefreshToken []byte, accessTokenExpiresAt, refreshTokenExpiresAt time.Time) (*models.AccessTokenModel, error)
 	Delete(accessToken *models.AccessTokenModel) error
 	DeleteMany(accessTokens []*models.AccessTokenModel) error
+	Prune(expiredBefore, deletedBefore time.Time, limit int) (int64, error)
 }
 
 type AccessTokenRepository struct {
@@ -37,6 +46,30 @@ func (repository *AccessTokenRepository) GetAll(ownerID uint, ownerType string)
 	return results, nil
 }
 
+// GetAllWithTrashed returns every token of the owner including the revoked ones.
+func (repository *AccessTokenRepository) GetAllWithTrashed(ownerID uint, ownerType string) ([]*models.AccessTokenModel, error) {
+	var results []*models.AccessTokenModel
+	res := repository.DatabaseHandler.GetClient().Unscoped().Where("owner_id = ?", ownerID).Where("owner_type = ?", ownerType).Find(&results)
+	if res.Error != nil {
+		return nil, fmt.Errorf("access token list retrieval failed: %s", res.Error)
+	}
+	return results, nil
+}
+
+// GetUnexpired returns the sessions of the owner which can still be refreshed, the least recently used first.
+func (repository *AccessTokenRepository) GetUnexpired(ownerID uint, ownerType string) ([]*models.AccessTokenModel, error) {
+	var results []*models.AccessTokenModel
+	res := repository.DatabaseHandler.GetClient().
+		Where("owner_id = ?", ownerID).Where("owner_type = ?", ownerType).
+		Where("refresh_token_expires_at > ?", time.Now()).
+		Order("coalesce(last_used_at, created_at)").Order("id").
+		Find(&results)
+	if res.Error != nil {
+		return nil, fmt.Errorf("access token list retrieval failed: %s", res.Error)
+	}
+	return results, nil
+}
+
 func (repository *AccessTokenRepository) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
 	var results []*models.AccessTokenModel
 
@@ -93,9 +126,21 @@ func (repository *AccessTokenRepository) Create(accessToken *models.AccessTokenM
 		return nil, err
 	}
 
-	result := repository.DatabaseHandler.GetClient().Create(&accessToken)
-	if result.Error != nil {
-		return nil, fmt.Errorf("access token creation failed: %s", result.Error.Error())
+	// the device joins the known devices of the owner along with its first token
+	err = repository.DatabaseHandler.GetClient().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&accessToken).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.KnownDeviceModel{
+			OwnerID:     accessToken.OwnerID,
+			OwnerType:   accessToken.OwnerType,
+			IP:          accessToken.IP,
+			UserAgent:   accessToken.UserAgent,
+			FirstSeenAt: accessToken.CreatedAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("access token creation failed: %s", err.Error())
 	}
 	return accessToken, nil
 }
@@ -114,6 +159,50 @@ func (repository *AccessTokenRepository) GetByUuid(accessTokenUuid *uuid.UUID) (
 	return &accessToken, nil
 }
 
+func (repository *AccessTokenRepository) GetByID(id uint) (*models.AccessTokenModel, error) {
+	var accessToken models.AccessTokenModel
+	result := repository.DatabaseHandler.GetClient().First(&accessToken, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("access token get by id failed: %s", result.Error.Error())
+	}
+	return &accessToken, nil
+}
+
+// CountDevices counts every device the owner was ever issued a token from, the pruned tokens included.
+func (repository *AccessTokenRepository) CountDevices(ownerID uint, ownerType string) (int64, error) {
+	var count int64
+	res := repository.DatabaseHandler.GetClient().Model(&models.KnownDeviceModel{}).
+		Where("owner_id = ?", ownerID).Where("owner_type = ?", ownerType).Count(&count)
+	if res.Error != nil {
+		return 0, fmt.Errorf("known device count failed: %s", res.Error)
+	}
+	return count, nil
+}
+
+// HasDevice reports whether the owner was ever issued a token from the ip and user agent, the pruned tokens included.
+func (repository *AccessTokenRepository) HasDevice(ownerID uint, ownerType, ip, userAgent string) (bool, error) {
+	var count int64
+	res := repository.DatabaseHandler.GetClient().Model(&models.KnownDeviceModel{}).
+		Where("owner_id = ?", ownerID).Where("owner_type = ?", ownerType).
+		Where("ip = ?", ip).Where("user_agent = ?", userAgent).Count(&count)
+	if res.Error != nil {
+		return false, fmt.Errorf("known device lookup failed: %s", res.Error)
+	}
+	return count > 0, nil
+}
+
+// GetDevices retrieve every device the owner was ever issued a token from
+func (repository *AccessTokenRepository) GetDevices(ownerID uint, ownerType string) ([]*models.KnownDeviceModel, error) {
+	var results []*models.KnownDeviceModel
+	res := repository.DatabaseHandler.GetClient().
+		Where("owner_id = ?", ownerID).Where("owner_type = ?", ownerType).
+		Order("first_seen_at").Find(&results)
+	if res.Error != nil {
+		return nil, fmt.Errorf("known devices retrieval failed: %s", res.Error)
+	}
+	return results, nil
+}
+
 func (repository *AccessTokenRepository) UpdateLastUsedAt(accessToken *models.AccessTokenModel, timestamp time.Time) (*models.AccessTokenModel, error) {
 	result := repository.DatabaseHandler.GetClient().Model(accessToken).Update("LastUsedAt", timestamp)
 	if result.Error != nil {
@@ -123,6 +212,20 @@ func (repository *AccessTokenRepository) UpdateLastUsedAt(accessToken *models.Ac
 	return accessToken, nil
 }
 
+// UpdateAuthTime stamps the time and method the owner of the session last authenticated with.
+func (repository *AccessTokenRepository) UpdateAuthTime(accessToken *models.AccessTokenModel, authTime time.Time, acr string) (*models.AccessTokenModel, error) {
+	result := repository.DatabaseHandler.GetClient().Model(accessToken).Updates(map[string]interface{}{
+		"auth_time": authTime,
+		"acr":       acr,
+	})
+	if result.Error != nil {
+		return nil, fmt.Errorf("access token auth time update failed: %s", result.Error)
+	}
+	accessToken.AuthTime = &authTime
+	accessToken.Acr = acr
+	return accessToken, nil
+}
+
 func (repository *AccessTokenRepository) RefreshAccessTokens(accessToken *models.AccessTokenModel, uuid uuid.UUID, newAccessToken, newRefreshToken []byte, accessTokenExpiresAt, refreshTokenExpiresAt time.Time) (*models.AccessTokenModel, error) {
 	var err error
 
@@ -164,3 +267,18 @@ func (repository *AccessTokenRepository) DeleteMany(accessTokens []*models.Acces
 	}
 	return nil
 }
+
+// Prune hard deletes at most limit tokens whose refresh token expired before expiredBefore
+// or which were revoked before deletedBefore, it returns the number of deleted rows.
+func (repository *AccessTokenRepository) Prune(expiredBefore, deletedBefore time.Time, limit int) (int64, error) {
+	client := repository.DatabaseHandler.GetClient()
+	batch := client.Unscoped().Model(&models.AccessTokenModel{}).Select("id").
+		Where("refresh_token_expires_at < ? OR deleted_at < ?", expiredBefore, deletedBefore).
+		Limit(limit)
+
+	result := client.Unscoped().Where("id IN (?)", batch).Delete(&models.AccessTokenModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("access tokens prune failed: %s", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"fmt"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/hash"
+	"go-auth-otp-service/src/models"
+)
+
+// IAdminRepository interface defines the methods to interact with the Admin data store.
+type IAdminRepository interface {
+	GetByID(id uint) (*models.AdminModel, error)
+	GetByUsername(username string) (*models.AdminModel, error)
+	Create(admin *models.AdminModel) (*models.AdminModel, error)
+	Update(admin *models.AdminModel) (*models.AdminModel, error)
+	UseTotpStep(adminID uint, step int64) (bool, error)
+}
+
+// AdminRepository struct implements the IAdminRepository interface.
+type AdminRepository struct {
+	DatabaseHandler *database.Database
+}
+
+// GetByID retrieve an admin by id
+func (repository *AdminRepository) GetByID(id uint) (*models.AdminModel, error) {
+	var admin models.AdminModel
+	result := repository.DatabaseHandler.GetClient().First(&admin, "id = ?", id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("admin get by id failed: %s", result.Error.Error())
+	}
+
+	return &admin, nil
+}
+
+// GetByUsername retrieve an admin by username
+func (repository *AdminRepository) GetByUsername(username string) (*models.AdminModel, error) {
+	var admin models.AdminModel
+	result := repository.DatabaseHandler.GetClient().First(&admin, "username = ?", username)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &admin, nil
+}
+
+// Create hashes the password and inserts a new admin into the database
+func (repository *AdminRepository) Create(admin *models.AdminModel) (*models.AdminModel, error) {
+	var err error
+
+	admin.Password, err = hash.GetInstance().Generate(admin.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	result := repository.DatabaseHandler.GetClient().Create(&admin)
+	if result.Error != nil {
+		return nil, fmt.Errorf("admin creation failed: %s", result.Error.Error())
+	}
+	return admin, nil
+}
+
+// Update update an admin
+func (repository *AdminRepository) Update(admin *models.AdminModel) (*models.AdminModel, error) {
+	result := repository.DatabaseHandler.GetClient().Save(&admin)
+	if result.Error != nil {
+		return nil, fmt.Errorf("admin update failed: %s", result.Error.Error())
+	}
+	return admin, nil
+}
+
+// UseTotpStep records the time step of an accepted totp code. It reports false when the step, or a later one, was
+// already used, so two requests racing with the same code can not both succeed.
+func (repository *AdminRepository) UseTotpStep(adminID uint, step int64) (bool, error) {
+	result := repository.DatabaseHandler.GetClient().Model(&models.AdminModel{}).
+		Where("id = ? AND totp_last_step < ?", adminID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, fmt.Errorf("admin totp step update failed: %s", result.Error.Error())
+	}
+	return result.RowsAffected == 1, nil
+}
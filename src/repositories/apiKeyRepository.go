@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/hash"
+	"go-auth-otp-service/src/models"
+	"time"
+)
+
+type IApiKeyRepository interface {
+	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	GetAll(ownerID uint, ownerType string) ([]*models.ApiKeyModel, error)
+	GetByUuid(apiKeyUuid *uuid.UUID) (*models.ApiKeyModel, error)
+	GetByKeyID(keyID string) (*models.ApiKeyModel, error)
+	Create(apiKey *models.ApiKeyModel) (*models.ApiKeyModel, error)
+	UpdateLastUsedAt(apiKey *models.ApiKeyModel, timestamp time.Time) (*models.ApiKeyModel, error)
+	Delete(apiKey *models.ApiKeyModel) error
+	DeleteAll(ownerID uint, ownerType string) error
+}
+
+type ApiKeyRepository struct {
+	DatabaseHandler *database.Database
+}
+
+func (repository *ApiKeyRepository) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	var results []*models.ApiKeyModel
+
+	// Get the database client
+	db := repository.DatabaseHandler.GetClient().Model(results)
+
+	// Apply pagination, filtering, and sorting using the BuilderModel
+	db, err := builder.QueryBuilderScope(db)
+	if err != nil {
+		return nil, fmt.Errorf("api key list retrieval failed: %s", err.Error())
+	}
+
+	// Create the PaginateModel and execute the query
+	paginateModel, err := builder.CreatePaginateModel(db, &results)
+	if err != nil {
+		return nil, fmt.Errorf("api key list retrieval failed: %s", err.Error())
+	}
+	return paginateModel, nil
+}
+
+// GetAll returns every api key of the owner.
+func (repository *ApiKeyRepository) GetAll(ownerID uint, ownerType string) ([]*models.ApiKeyModel, error) {
+	var results []*models.ApiKeyModel
+	res := repository.DatabaseHandler.GetClient().Where("owner_id = ?", ownerID).Where("owner_type = ?", ownerType).Find(&results)
+	if res.Error != nil {
+		return nil, fmt.Errorf("api key list retrieval failed: %s", res.Error)
+	}
+	return results, nil
+}
+
+func (repository *ApiKeyRepository) GetByUuid(apiKeyUuid *uuid.UUID) (*models.ApiKeyModel, error) {
+	var apiKey models.ApiKeyModel
+	result := repository.DatabaseHandler.GetClient().First(&apiKey, "uuid = ?", apiKeyUuid)
+	if result.Error != nil {
+		return nil, fmt.Errorf("api key get by uuid failed: %s", result.Error.Error())
+	}
+
+	return &apiKey, nil
+}
+
+func (repository *ApiKeyRepository) GetByKeyID(keyID string) (*models.ApiKeyModel, error) {
+	var apiKey models.ApiKeyModel
+	result := repository.DatabaseHandler.GetClient().First(&apiKey, "key_id = ?", keyID)
+	if result.Error != nil {
+		return nil, fmt.Errorf("api key get by key id failed: %s", result.Error.Error())
+	}
+
+	return &apiKey, nil
+}
+
+func (repository *ApiKeyRepository) Create(apiKey *models.ApiKeyModel) (*models.ApiKeyModel, error) {
+	var err error
+
+	apiKey.Key, err = hash.GetInstance().Generate(apiKey.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := repository.DatabaseHandler.GetClient().Create(&apiKey)
+	if result.Error != nil {
+		return nil, fmt.Errorf("api key creation failed: %s", result.Error.Error())
+	}
+	return apiKey, nil
+}
+
+func (repository *ApiKeyRepository) UpdateLastUsedAt(apiKey *models.ApiKeyModel, timestamp time.Time) (*models.ApiKeyModel, error) {
+	result := repository.DatabaseHandler.GetClient().Model(apiKey).Update("LastUsedAt", timestamp)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	apiKey.LastUsedAt = &timestamp
+	return apiKey, nil
+}
+
+func (repository *ApiKeyRepository) Delete(apiKey *models.ApiKeyModel) error {
+	err := repository.DatabaseHandler.GetClient().Delete(&apiKey)
+	if err.Error != nil {
+		return fmt.Errorf("api key destroy failed: %s", err.Error.Error())
+	}
+	return nil
+}
+
+// DeleteAll revokes every api key of the owner.
+func (repository *ApiKeyRepository) DeleteAll(ownerID uint, ownerType string) error {
+	err := repository.DatabaseHandler.GetClient().Where("owner_id = ? AND owner_type = ?", ownerID, ownerType).Delete(&models.ApiKeyModel{})
+	if err.Error != nil {
+		return fmt.Errorf("api key destroy failed: %s", err.Error.Error())
+	}
+	return nil
+}
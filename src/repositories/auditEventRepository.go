@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"fmt"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/models"
+)
+
+// IAuditEventRepository interface defines the methods to interact with the audit log.
+// Audit events are append-only, there is no way to update or delete them.
+type IAuditEventRepository interface {
+	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	GetAll(ownerID uint, ownerType string) ([]*models.AuditEventModel, error)
+	Create(auditEvent *models.AuditEventModel) (*models.AuditEventModel, error)
+}
+
+// AuditEventRepository struct implements the IAuditEventRepository interface.
+type AuditEventRepository struct {
+	DatabaseHandler *database.Database
+}
+
+// GetList retrieve audit events
+func (repository *AuditEventRepository) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	var results []*models.AuditEventModel
+
+	// Get the database client
+	db := repository.DatabaseHandler.GetClient().Model(results)
+
+	// Apply pagination, filtering, and sorting using the BuilderModel
+	db, err := builder.QueryBuilderScope(db)
+	if err != nil {
+		return nil, fmt.Errorf("audit event list retrieval failed: %s", err.Error())
+	}
+
+	// Create the PaginateModel and execute the query
+	paginateModel, err := builder.CreatePaginateModel(db, &results)
+	if err != nil {
+		return nil, fmt.Errorf("audit event list retrieval failed: %s", err.Error())
+	}
+	return paginateModel, nil
+}
+
+// GetAll returns every audit event of the owner.
+func (repository *AuditEventRepository) GetAll(ownerID uint, ownerType string) ([]*models.AuditEventModel, error) {
+	var results []*models.AuditEventModel
+	res := repository.DatabaseHandler.GetClient().Where("owner_id = ?", ownerID).Where("owner_type = ?", ownerType).Order("id").Find(&results)
+	if res.Error != nil {
+		return nil, fmt.Errorf("audit event list retrieval failed: %s", res.Error)
+	}
+	return results, nil
+}
+
+// Create appends an audit event
+func (repository *AuditEventRepository) Create(auditEvent *models.AuditEventModel) (*models.AuditEventModel, error) {
+	result := repository.DatabaseHandler.GetClient().Create(&auditEvent)
+	if result.Error != nil {
+		return nil, fmt.Errorf("audit event creation failed: %s", result.Error.Error())
+	}
+	return auditEvent, nil
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"fmt"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/hash"
+	"go-auth-otp-service/src/models"
+)
+
+// IClientRepository interface defines the methods to interact with the Client data store.
+type IClientRepository interface {
+	GetByID(id uint) (*models.ClientModel, error)
+	GetByClientID(clientID string) (*models.ClientModel, error)
+	Create(client *models.ClientModel) (*models.ClientModel, error)
+}
+
+// ClientRepository struct implements the IClientRepository interface.
+type ClientRepository struct {
+	DatabaseHandler *database.Database
+}
+
+// GetByID gets a client by id.
+func (repository *ClientRepository) GetByID(id uint) (*models.ClientModel, error) {
+	var client models.ClientModel
+	result := repository.DatabaseHandler.GetClient().First(&client, "id = ?", id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("client get by id failed: %s", result.Error.Error())
+	}
+
+	return &client, nil
+}
+
+// GetByClientID gets a client by its public client id.
+func (repository *ClientRepository) GetByClientID(clientID string) (*models.ClientModel, error) {
+	var client models.ClientModel
+	result := repository.DatabaseHandler.GetClient().First(&client, "client_id = ?", clientID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &client, nil
+}
+
+// Create hashes the client secret and inserts a new client into the database.
+func (repository *ClientRepository) Create(client *models.ClientModel) (*models.ClientModel, error) {
+	var err error
+
+	client.ClientSecret, err = hash.GetInstance().Generate(client.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	result := repository.DatabaseHandler.GetClient().Create(&client)
+	if result.Error != nil {
+		return nil, fmt.Errorf("client creation failed: %s", result.Error.Error())
+	}
+	return client, nil
+}
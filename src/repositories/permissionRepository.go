@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"fmt"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/models"
+)
+
+// IPermissionRepository interface defines the methods to interact with the permissions.
+type IPermissionRepository interface {
+	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	GetOwnerPermissions(ownerID uint, ownerType string) ([]string, error)
+}
+
+// PermissionRepository struct implements the IPermissionRepository interface.
+type PermissionRepository struct {
+	DatabaseHandler *database.Database
+}
+
+// GetList retrieve all permissions
+func (repository *PermissionRepository) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	var results []*models.PermissionModel
+
+	// Get the database client
+	db := repository.DatabaseHandler.GetClient().Model(results)
+
+	// Apply pagination, filtering, and sorting using the BuilderModel
+	db, err := builder.QueryBuilderScope(db)
+	if err != nil {
+		return nil, fmt.Errorf("permission list retrieval failed: %s", err.Error())
+	}
+
+	// Create the PaginateModel and execute the query
+	paginateModel, err := builder.CreatePaginateModel(db, &results)
+	if err != nil {
+		return nil, fmt.Errorf("permission list retrieval failed: %s", err.Error())
+	}
+	return paginateModel, nil
+}
+
+// GetOwnerPermissions retrieve the names of every permission granted to an owner through its roles
+func (repository *PermissionRepository) GetOwnerPermissions(ownerID uint, ownerType string) ([]string, error) {
+	var results []string
+	result := repository.DatabaseHandler.GetClient().Model(&models.PermissionModel{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN owner_roles ON owner_roles.role_id = role_permissions.role_id").
+		Where("owner_roles.owner_id = ? AND owner_roles.owner_type = ?", ownerID, ownerType).
+		Order("permissions.name").
+		Pluck("permissions.name", &results)
+	if result.Error != nil {
+		return nil, fmt.Errorf("owner permissions retrieval failed: %s", result.Error.Error())
+	}
+	return results, nil
+}
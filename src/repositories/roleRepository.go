@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"fmt"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/models"
+	"gorm.io/gorm/clause"
+)
+
+// IRoleRepository interface defines the methods to interact with the roles and their assignments.
+type IRoleRepository interface {
+	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	GetByName(name string) (*models.RoleModel, error)
+	GetOwnerRoles(ownerID uint, ownerType string) ([]*models.RoleModel, error)
+	Assign(ownerID uint, ownerType string, role *models.RoleModel) error
+	Revoke(ownerID uint, ownerType string, role *models.RoleModel) error
+}
+
+// RoleRepository struct implements the IRoleRepository interface.
+type RoleRepository struct {
+	DatabaseHandler *database.Database
+}
+
+// GetList retrieve all roles with their permissions
+func (repository *RoleRepository) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	var results []*models.RoleModel
+
+	// Get the database client
+	db := repository.DatabaseHandler.GetClient().Model(results)
+
+	// Apply pagination, filtering, and sorting using the BuilderModel
+	builder.Relations = append(builder.Relations, "Permissions")
+	db, err := builder.QueryBuilderScope(db)
+	if err != nil {
+		return nil, fmt.Errorf("role list retrieval failed: %s", err.Error())
+	}
+
+	// Create the PaginateModel and execute the query
+	paginateModel, err := builder.CreatePaginateModel(db, &results)
+	if err != nil {
+		return nil, fmt.Errorf("role list retrieval failed: %s", err.Error())
+	}
+	return paginateModel, nil
+}
+
+// GetByName retrieve a role by its name
+func (repository *RoleRepository) GetByName(name string) (*models.RoleModel, error) {
+	var role models.RoleModel
+	result := repository.DatabaseHandler.GetClient().First(&role, "name = ?", name)
+	if result.Error != nil {
+		return nil, fmt.Errorf("role get by name failed: %s", result.Error.Error())
+	}
+
+	return &role, nil
+}
+
+// GetOwnerRoles retrieve the roles assigned to an owner
+func (repository *RoleRepository) GetOwnerRoles(ownerID uint, ownerType string) ([]*models.RoleModel, error) {
+	var results []*models.RoleModel
+	result := repository.DatabaseHandler.GetClient().
+		Joins("JOIN owner_roles ON owner_roles.role_id = roles.id").
+		Where("owner_roles.owner_id = ? AND owner_roles.owner_type = ?", ownerID, ownerType).
+		Find(&results)
+	if result.Error != nil {
+		return nil, fmt.Errorf("owner roles retrieval failed: %s", result.Error.Error())
+	}
+	return results, nil
+}
+
+// Assign assigns a role to an owner, assigning an already assigned role is a no-op
+func (repository *RoleRepository) Assign(ownerID uint, ownerType string, role *models.RoleModel) error {
+	result := repository.DatabaseHandler.GetClient().Clauses(clause.OnConflict{DoNothing: true}).Create(&models.OwnerRoleModel{
+		OwnerID:   ownerID,
+		OwnerType: ownerType,
+		RoleID:    role.ID,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("role assignment failed: %s", result.Error.Error())
+	}
+	return nil
+}
+
+// Revoke removes a role from an owner
+func (repository *RoleRepository) Revoke(ownerID uint, ownerType string, role *models.RoleModel) error {
+	result := repository.DatabaseHandler.GetClient().
+		Where("owner_id = ? AND owner_type = ? AND role_id = ?", ownerID, ownerType, role.ID).
+		Delete(&models.OwnerRoleModel{})
+	if result.Error != nil {
+		return fmt.Errorf("role revocation failed: %s", result.Error.Error())
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/hash"
+	"go-auth-otp-service/src/models"
+	"time"
+)
+
+// ErrTrustedDeviceTokenRotated is returned when the token of the trusted device was rotated by another request first.
+var ErrTrustedDeviceTokenRotated = errors.New("trusted device token was already rotated")
+
+type ITrustedDeviceRepository interface {
+	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	GetByUuid(trustedDeviceUuid *uuid.UUID) (*models.TrustedDeviceModel, error)
+	GetByTokenID(tokenID string) (*models.TrustedDeviceModel, error)
+	Create(trustedDevice *models.TrustedDeviceModel) (*models.TrustedDeviceModel, error)
+	RotateToken(trustedDevice *models.TrustedDeviceModel, tokenID string, token []byte, timestamp time.Time) (*models.TrustedDeviceModel, error)
+	Delete(trustedDevice *models.TrustedDeviceModel) error
+	DeleteAll(userID uint) error
+	DeleteByAccessToken(accessTokenID uint) error
+}
+
+type TrustedDeviceRepository struct {
+	DatabaseHandler *database.Database
+}
+
+func (repository *TrustedDeviceRepository) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	var results []*models.TrustedDeviceModel
+
+	// Get the database client
+	db := repository.DatabaseHandler.GetClient().Model(results)
+
+	// Apply pagination, filtering, and sorting using the BuilderModel
+	db, err := builder.QueryBuilderScope(db)
+	if err != nil {
+		return nil, fmt.Errorf("trusted device list retrieval failed: %s", err.Error())
+	}
+
+	// Create the PaginateModel and execute the query
+	paginateModel, err := builder.CreatePaginateModel(db, &results)
+	if err != nil {
+		return nil, fmt.Errorf("trusted device list retrieval failed: %s", err.Error())
+	}
+	return paginateModel, nil
+}
+
+func (repository *TrustedDeviceRepository) GetByUuid(trustedDeviceUuid *uuid.UUID) (*models.TrustedDeviceModel, error) {
+	var trustedDevice models.TrustedDeviceModel
+	result := repository.DatabaseHandler.GetClient().First(&trustedDevice, "uuid = ?", trustedDeviceUuid)
+	if result.Error != nil {
+		return nil, fmt.Errorf("trusted device get by uuid failed: %s", result.Error.Error())
+	}
+
+	return &trustedDevice, nil
+}
+
+func (repository *TrustedDeviceRepository) GetByTokenID(tokenID string) (*models.TrustedDeviceModel, error) {
+	var trustedDevice models.TrustedDeviceModel
+	result := repository.DatabaseHandler.GetClient().First(&trustedDevice, "token_id = ?", tokenID)
+	if result.Error != nil {
+		return nil, fmt.Errorf("trusted device get by token id failed: %s", result.Error.Error())
+	}
+
+	return &trustedDevice, nil
+}
+
+func (repository *TrustedDeviceRepository) Create(trustedDevice *models.TrustedDeviceModel) (*models.TrustedDeviceModel, error) {
+	var err error
+
+	trustedDevice.Token, err = hash.GetInstance().Generate(trustedDevice.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	result := repository.DatabaseHandler.GetClient().Create(&trustedDevice)
+	if result.Error != nil {
+		return nil, fmt.Errorf("trusted device creation failed: %s", result.Error.Error())
+	}
+	return trustedDevice, nil
+}
+
+// RotateToken replaces the token of the trusted device and marks it as used, the previous token stops working.
+// Only the request still holding the current token id wins, so two logins racing with the same token can not both succeed.
+func (repository *TrustedDeviceRepository) RotateToken(trustedDevice *models.TrustedDeviceModel, tokenID string, token []byte, timestamp time.Time) (*models.TrustedDeviceModel, error) {
+	hashedToken, err := hash.GetInstance().Generate(token)
+	if err != nil {
+		return nil, err
+	}
+
+	result := repository.DatabaseHandler.GetClient().Model(trustedDevice).
+		Where("token_id = ?", trustedDevice.TokenID).
+		Updates(map[string]interface{}{
+			"token_id":     tokenID,
+			"token":        hashedToken,
+			"last_used_at": timestamp,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("trusted device token rotation failed: %s", result.Error.Error())
+	}
+	if result.RowsAffected != 1 {
+		return nil, ErrTrustedDeviceTokenRotated
+	}
+
+	trustedDevice.TokenID = tokenID
+	trustedDevice.Token = hashedToken
+	trustedDevice.LastUsedAt = &timestamp
+	return trustedDevice, nil
+}
+
+func (repository *TrustedDeviceRepository) Delete(trustedDevice *models.TrustedDeviceModel) error {
+	err := repository.DatabaseHandler.GetClient().Delete(&trustedDevice)
+	if err.Error != nil {
+		return fmt.Errorf("trusted device destroy failed: %s", err.Error.Error())
+	}
+	return nil
+}
+
+// DeleteAll revokes every trusted device of the user.
+func (repository *TrustedDeviceRepository) DeleteAll(userID uint) error {
+	err := repository.DatabaseHandler.GetClient().Where("user_id = ?", userID).Delete(&models.TrustedDeviceModel{})
+	if err.Error != nil {
+		return fmt.Errorf("trusted device destroy failed: %s", err.Error.Error())
+	}
+	return nil
+}
+
+// DeleteByAccessToken revokes the trusted device created by the session.
+func (repository *TrustedDeviceRepository) DeleteByAccessToken(accessTokenID uint) error {
+	err := repository.DatabaseHandler.GetClient().Where("access_token_id = ?", accessTokenID).Delete(&models.TrustedDeviceModel{})
+	if err.Error != nil {
+		return fmt.Errorf("trusted device destroy failed: %s", err.Error.Error())
+	}
+	return nil
+}
@@ -6,17 +6,30 @@ import (
 	"go-auth-otp-service/src/database"
 	"go-auth-otp-service/src/database/scopes"
 	"go-auth-otp-service/src/models"
+	"gorm.io/gorm"
+	"time"
 )
 
 // IUserRepository interface defines the methods to interact with the User data store.
 type IUserRepository interface {
 	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	GetByID(id uint) (*models.UserModel, error)
 	GetByUuid(uuid *uuid.UUID) (*models.UserModel, error)
+	GetByUuidWithTrashed(uuid *uuid.UUID) (*models.UserModel, error)
 	GetByNationalIdentityCode(nationalIdentityCode string) (*models.UserModel, error)
+	GetByNationalIdentityCodeWithTrashed(nationalIdentityCode string) (*models.UserModel, error)
 	GetByMobile(mobile string) (*models.UserModel, error)
+	GetByMobileWithTrashed(mobile string) (*models.UserModel, error)
+	GetByEmail(email string) (*models.UserModel, error)
+	GetByEmailWithTrashed(email string) (*models.UserModel, error)
 	Create(user *models.UserModel) (*models.UserModel, error)
 	Update(user *models.UserModel) (*models.UserModel, error)
 	Delete(user *models.UserModel) error
+	Restore(user *models.UserModel) (*models.UserModel, error)
+	ForceDelete(user *models.UserModel) error
+	RequestDeletion(user *models.UserModel) error
+	GetDeletionRequestedBefore(before time.Time, limit int) ([]*models.UserModel, error)
+	Anonymize(user *models.UserModel) error
 }
 
 // UserRepository struct implements the UserRepository interface.
@@ -45,6 +58,17 @@ func (repository *UserRepository) GetList(builder *scopes.BuilderModel) (*scopes
 	return paginateModel, nil
 }
 
+// GetByID retrieve a user by id
+func (repository *UserRepository) GetByID(id uint) (*models.UserModel, error) {
+	var user models.UserModel
+	result := repository.DatabaseHandler.GetClient().First(&user, "id = ?", id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("user get by id failed: %s", result.Error.Error())
+	}
+
+	return &user, nil
+}
+
 // GetByUuid retrieve a user by uuid
 func (repository *UserRepository) GetByUuid(uuid *uuid.UUID) (*models.UserModel, error) {
 	var user models.UserModel
@@ -56,6 +80,17 @@ func (repository *UserRepository) GetByUuid(uuid *uuid.UUID) (*models.UserModel,
 	return &user, nil
 }
 
+// GetByUuidWithTrashed retrieve a user by uuid including soft-deleted users
+func (repository *UserRepository) GetByUuidWithTrashed(uuid *uuid.UUID) (*models.UserModel, error) {
+	var user models.UserModel
+	result := repository.DatabaseHandler.GetClient().Unscoped().First(&user, "uuid = ?", uuid)
+	if result.Error != nil {
+		return nil, fmt.Errorf("user get by uuid failed: %s", result.Error.Error())
+	}
+
+	return &user, nil
+}
+
 // GetByNationalIdentityCode gets a user by national-identity-code.
 func (repository *UserRepository) GetByNationalIdentityCode(nationalIdentityCode string) (*models.UserModel, error) {
 	var user models.UserModel
@@ -67,6 +102,17 @@ func (repository *UserRepository) GetByNationalIdentityCode(nationalIdentityCode
 	return &user, nil
 }
 
+// GetByNationalIdentityCodeWithTrashed gets a user by national-identity-code including soft-deleted users.
+func (repository *UserRepository) GetByNationalIdentityCodeWithTrashed(nationalIdentityCode string) (*models.UserModel, error) {
+	var user models.UserModel
+	result := repository.DatabaseHandler.GetClient().Unscoped().First(&user, "national_identity_code = ?", nationalIdentityCode)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
 // GetByMobile gets a user by mobile .
 func (repository *UserRepository) GetByMobile(mobile string) (*models.UserModel, error) {
 	var user models.UserModel
@@ -78,6 +124,39 @@ func (repository *UserRepository) GetByMobile(mobile string) (*models.UserModel,
 	return &user, nil
 }
 
+// GetByMobileWithTrashed gets a user by mobile including soft-deleted users.
+func (repository *UserRepository) GetByMobileWithTrashed(mobile string) (*models.UserModel, error) {
+	var user models.UserModel
+	result := repository.DatabaseHandler.GetClient().Unscoped().First(&user, "mobile = ?", mobile)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
+// GetByEmail gets a user by email.
+func (repository *UserRepository) GetByEmail(email string) (*models.UserModel, error) {
+	var user models.UserModel
+	result := repository.DatabaseHandler.GetClient().First(&user, "email = ?", email)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
+// GetByEmailWithTrashed gets a user by email including soft-deleted users.
+func (repository *UserRepository) GetByEmailWithTrashed(email string) (*models.UserModel, error) {
+	var user models.UserModel
+	result := repository.DatabaseHandler.GetClient().Unscoped().First(&user, "email = ?", email)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
 // Create inserts a new User into the database
 func (repository *UserRepository) Create(user *models.UserModel) (*models.UserModel, error) {
 	result := repository.DatabaseHandler.GetClient().Create(&user)
@@ -87,9 +166,19 @@ func (repository *UserRepository) Create(user *models.UserModel) (*models.UserMo
 	return user, nil
 }
 
-// Update update a user
+// Update writes the attributes of a user, the empty optional ones are written as NULL so they stay out of the unique
+// indexes of their columns
 func (repository *UserRepository) Update(user *models.UserModel) (*models.UserModel, error) {
-	result := repository.DatabaseHandler.GetClient().Save(&user)
+	result := repository.DatabaseHandler.GetClient().Model(&user).Updates(map[string]interface{}{
+		"is_active":              user.IsActive,
+		"first_name":             nullable(user.FirstName),
+		"last_name":              nullable(user.LastName),
+		"father_name":            nullable(user.FatherName),
+		"profile_image":          nullable(user.ProfileImage),
+		"national_identity_code": nullable(user.NationalIdentityCode),
+		"mobile":                 user.Mobile,
+		"email":                  nullable(user.Email),
+	})
 	if result.Error != nil {
 		return nil, fmt.Errorf("user update failed: %s", result.Error.Error())
 	}
@@ -104,3 +193,125 @@ func (repository *UserRepository) Delete(user *models.UserModel) error {
 	}
 	return nil
 }
+
+// Restore clears the soft-delete mark of a user
+func (repository *UserRepository) Restore(user *models.UserModel) (*models.UserModel, error) {
+	result := repository.DatabaseHandler.GetClient().Unscoped().Model(&user).Updates(map[string]interface{}{
+		"deleted_at":            nil,
+		"deletion_requested_at": nil,
+	})
+	if result.Error != nil {
+		return nil, fmt.Errorf("user restore failed: %s", result.Error.Error())
+	}
+	user.DeletedAt = gorm.DeletedAt{}
+	user.DeletionRequestedAt = nil
+	return user, nil
+}
+
+// ForceDelete permanently removes a user alongside every token, known device, api key and role owned by it
+func (repository *UserRepository) ForceDelete(user *models.UserModel) error {
+	err := repository.DatabaseHandler.GetClient().Transaction(func(tx *gorm.DB) error {
+		owned := []interface{}{&models.AccessTokenModel{}, &models.KnownDeviceModel{}, &models.ApiKeyModel{}, &models.OwnerRoleModel{}}
+		for _, model := range owned {
+			if err := tx.Unscoped().Where("owner_id = ? AND owner_type = ?", user.ID, "user").Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Delete(&user).Error
+	})
+	if err != nil {
+		return fmt.Errorf("user force delete failed: %s", err.Error())
+	}
+	return nil
+}
+
+// RequestDeletion marks the user as deleted on their own request, which starts the anonymisation grace period
+func (repository *UserRepository) RequestDeletion(user *models.UserModel) error {
+	now := time.Now()
+	err := repository.DatabaseHandler.GetClient().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Update("deletion_requested_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&user).Error
+	})
+	if err != nil {
+		return fmt.Errorf("user deletion request failed: %s", err.Error())
+	}
+	user.DeletionRequestedAt = &now
+	return nil
+}
+
+// GetDeletionRequestedBefore retrieve users whose deletion was requested before the given time and are not anonymised yet
+func (repository *UserRepository) GetDeletionRequestedBefore(before time.Time, limit int) ([]*models.UserModel, error) {
+	var results []*models.UserModel
+	result := repository.DatabaseHandler.GetClient().Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Where("deletion_requested_at < ?", before).
+		Where("anonymized_at IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(&results)
+	if result.Error != nil {
+		return nil, fmt.Errorf("user deletion requests retrieval failed: %s", result.Error.Error())
+	}
+	return results, nil
+}
+
+// Anonymize erases the personal data of a deleted user alongside every token, known device, api key, role and trusted
+// device owned by it, and clears the ip, user agent, location and metadata of its audit events. The rows themselves are
+// kept so references to the user stay valid.
+func (repository *UserRepository) Anonymize(user *models.UserModel) error {
+	now := time.Now()
+	err := repository.DatabaseHandler.GetClient().Transaction(func(tx *gorm.DB) error {
+		owned := []interface{}{&models.AccessTokenModel{}, &models.KnownDeviceModel{}, &models.ApiKeyModel{}, &models.OwnerRoleModel{}}
+		for _, model := range owned {
+			if err := tx.Unscoped().Where("owner_id = ? AND owner_type = ?", user.ID, "user").Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&models.TrustedDeviceModel{}).Error; err != nil {
+			return err
+		}
+
+		// audit events are append-only, the trigger only lets this transaction clear their personal columns
+		if err := tx.Exec("SET LOCAL audit_events.redact = 'on'").Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.AuditEventModel{}).Where("owner_id = ? AND owner_type = ?", user.ID, "user").
+			Updates(map[string]interface{}{
+				"ip":           nil,
+				"user_agent":   nil,
+				"country_code": nil,
+				"country":      nil,
+				"city":         nil,
+				"metadata":     nil,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&user).Updates(map[string]interface{}{
+			"first_name":             nil,
+			"last_name":              nil,
+			"father_name":            nil,
+			"profile_image":          nil,
+			"password":               nil,
+			"national_identity_code": nil,
+			"email":                  nil,
+			"mobile":                 fmt.Sprintf("deleted-%d", user.ID),
+			"anonymized_at":          now,
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("user anonymization failed: %s", err.Error())
+	}
+	return nil
+}
+
+// nullable maps an empty string to NULL.
+func nullable(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"database/sql"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/models"
+	gormPsql "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRun is a database driver building the postgres statements without sending them.
+type dryRun struct {
+	client *gorm.DB
+}
+
+func (driver *dryRun) Connect() error      { return nil }
+func (driver *dryRun) Close() error        { return nil }
+func (driver *dryRun) GetClient() *gorm.DB { return driver.client }
+func (driver *dryRun) GetDB() *sql.DB      { db, _ := driver.client.DB(); return db }
+
+var assignment = regexp.MustCompile(`"(\w+)"=\$(\d+)`)
+
+// newDryRunUserRepository returns a user repository whose updates are collected as column assignments.
+func newDryRunUserRepository(t *testing.T) (*UserRepository, *[]map[string]interface{}) {
+	client, err := gorm.Open(gormPsql.New(gormPsql.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updates := &[]map[string]interface{}{}
+	err = client.Callback().Update().After("gorm:update").Register("test:collect", func(tx *gorm.DB) {
+		columns := map[string]interface{}{}
+		for _, match := range assignment.FindAllStringSubmatch(tx.Statement.SQL.String(), -1) {
+			position, _ := strconv.Atoi(match[2])
+			columns[match[1]] = tx.Statement.Vars[position-1]
+		}
+		*updates = append(*updates, columns)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &UserRepository{DatabaseHandler: database.New(&dryRun{client: client})}, updates
+}
+
+func TestUserRepositoryUpdateKeepsEmptyUniqueColumnsNull(t *testing.T) {
+	repository, updates := newDryRunUserRepository(t)
+
+	// neither user has an email or a national identity code, writing them as '' would collide on the unique indexes
+	users := []*models.UserModel{
+		{ID: 1, Mobile: "09123456789", FirstName: "Ali", IsActive: false},
+		{ID: 2, Mobile: "09351234567", ProfileImage: "a.jpg", IsActive: true},
+	}
+	for _, user := range users {
+		if _, err := repository.Update(user); err != nil {
+			t.Fatalf("Update(%d): %v", user.ID, err)
+		}
+	}
+
+	if len(*updates) != len(users) {
+		t.Fatalf("updates = %d, want %d", len(*updates), len(users))
+	}
+	for i, columns := range *updates {
+		for _, column := range []string{"email", "national_identity_code"} {
+			value, ok := columns[column]
+			if !ok || value != nil {
+				t.Errorf("user %d: %s = %#v (set %t), want NULL", users[i].ID, column, value, ok)
+			}
+		}
+		if columns["mobile"] != users[i].Mobile {
+			t.Errorf("user %d: mobile = %#v, want %q", users[i].ID, columns["mobile"], users[i].Mobile)
+		}
+		if columns["is_active"] != users[i].IsActive {
+			t.Errorf("user %d: is_active = %#v, want %t", users[i].ID, columns["is_active"], users[i].IsActive)
+		}
+	}
+	if (*updates)[0]["first_name"] != "Ali" || (*updates)[1]["profile_image"] != "a.jpg" {
+		t.Errorf("updates = %v, want the set attributes written", *updates)
+	}
+}
+
+func TestUserRepositoryUpdateWritesSetUniqueColumns(t *testing.T) {
+	repository, updates := newDryRunUserRepository(t)
+
+	user := &models.UserModel{ID: 1, Mobile: "09123456789", Email: "a@example.com", NationalIdentityCode: "0012345678"}
+	if _, err := repository.Update(user); err != nil {
+		t.Fatal(err)
+	}
+
+	columns := (*updates)[0]
+	if columns["email"] != "a@example.com" || columns["national_identity_code"] != "0012345678" {
+		t.Errorf("email, national_identity_code = %#v, %#v", columns["email"], columns["national_identity_code"])
+	}
+	if user.Email != "a@example.com" {
+		t.Errorf("user email = %q after Update", user.Email)
+	}
+}
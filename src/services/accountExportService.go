@@ -0,0 +1,89 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/repositories"
+	"sort"
+)
+
+type IAccountExportService interface {
+	Export(user *models.UserModel) (map[string]interface{}, error)
+	Archive(export map[string]interface{}) ([]byte, error)
+}
+
+type AccountExportService struct {
+	AccessTokenRepository repositories.IAccessTokenRepository
+	ApiKeyRepository      repositories.IApiKeyRepository
+	AuditEventRepository  repositories.IAuditEventRepository
+	AuthorizationService  IAuthorizationService
+}
+
+// Export gathers every piece of data kept about the user, keyed by its kind.
+func (service *AccountExportService) Export(user *models.UserModel) (map[string]interface{}, error) {
+	sessions, err := service.AccessTokenRepository.GetAllWithTrashed(user.ID, "user")
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	devices, err := service.AccessTokenRepository.GetDevices(user.ID, "user")
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	apiKeys, err := service.ApiKeyRepository.GetAll(user.ID, "user")
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	roles, err := service.AuthorizationService.GetOwnerRoles(user.ID, "user")
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	auditEvents, err := service.AuditEventRepository.GetAll(user.ID, "user")
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return map[string]interface{}{
+		"profile":      user,
+		"sessions":     sessions,
+		"devices":      devices,
+		"api_keys":     apiKeys,
+		"roles":        roles,
+		"audit_events": auditEvents,
+	}, nil
+}
+
+// Archive packs the export into a zip archive holding one json file per kind of data.
+func (service *AccountExportService) Archive(export map[string]interface{}) ([]byte, error) {
+	names := make([]string, 0, len(export))
+	for name := range export {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, name := range names {
+		file, err := archive.Create(name + ".json")
+		if err != nil {
+			return nil, errs.SomeThingWentWrong
+		}
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(export[name]); err != nil {
+			return nil, errs.SomeThingWentWrong
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/geoip"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/repositories"
+	"go.uber.org/zap"
+	"log"
+	"time"
+)
+
+// audit events
+const (
+	AuditOtpRequested          = "otp.requested"
+	AuditOtpVerified           = "otp.verified"
+	AuditOtpFailed             = "otp.failed"
+	AuditLogin                 = "login"
+	AuditNewDeviceLogin        = "login.new-device"
+	AuditTokenRefreshed        = "token.refreshed"
+	AuditTokenRevoked          = "token.revoked"
+	AuditTokensRevoked         = "tokens.revoked"
+	AuditUserRegistered        = "user.registered"
+	AuditUserCreated           = "user.created"
+	AuditProfileUpdated        = "profile.updated"
+	AuditUserDeactivated       = "user.deactivated"
+	AuditUserReactivated       = "user.reactivated"
+	AuditUserDeleted           = "user.deleted"
+	AuditUserRestored          = "user.restored"
+	AuditUserForceDeleted      = "user.force-deleted"
+	AuditUserDeletionRequested = "user.deletion-requested"
+	AuditUserAnonymized        = "user.anonymized"
+	AuditClientTokenIssued     = "client.token-issued"
+	AuditPasswordChecked       = "login.password-checked"
+	AuditStepUp                = "login.step-up"
+	AuditImpersonationStarted  = "impersonation.started"
+	AuditImpersonatedRequest   = "impersonation.request"
+)
+
+// audit outcomes
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditEntry describes an event about an owner, the request information is read from the context.
+type AuditEntry struct {
+	Event     string
+	Outcome   string
+	OwnerID   uint
+	OwnerType string
+	Metadata  map[string]interface{}
+}
+
+type IAuditService interface {
+	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	GetAll(ownerID uint, ownerType string) ([]*models.AuditEventModel, error)
+	Record(ctx context.Context, entry AuditEntry)
+}
+
+type AuditService struct {
+	AuditEventRepository repositories.IAuditEventRepository
+}
+
+func (service *AuditService) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	res, err := service.AuditEventRepository.GetList(builder)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return res, nil
+}
+
+func (service *AuditService) GetAll(ownerID uint, ownerType string) ([]*models.AuditEventModel, error) {
+	res, err := service.AuditEventRepository.GetAll(ownerID, ownerType)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return res, nil
+}
+
+// Record appends the entry to the audit log alongside the ip, user agent, request uuid and actor found in the context.
+// Failing to record never fails the audited action, the error is logged instead.
+func (service *AuditService) Record(ctx context.Context, entry AuditEntry) {
+	event := &models.AuditEventModel{
+		Uuid:      uuid.New(),
+		Event:     entry.Event,
+		Outcome:   entry.Outcome,
+		OwnerType: entry.OwnerType,
+		IP:        contextString(ctx, "request-ip"),
+		UserAgent: contextString(ctx, "request-user-agent"),
+	}
+
+	location := geoip.GetInstance().Lookup(event.IP)
+	event.CountryCode = location.CountryCode
+	event.Country = location.Country
+	event.City = location.City
+
+	if entry.OwnerID != 0 {
+		event.OwnerID = &entry.OwnerID
+	}
+	if actorID, ok := ctx.Value("actor-id").(uint); ok && actorID != 0 {
+		event.ActorID = &actorID
+		event.ActorType = contextString(ctx, "actor-type")
+	}
+	if requestUuid, err := uuid.Parse(contextString(ctx, "request-uuid")); err == nil {
+		event.RequestUuid = &requestUuid
+	}
+	if len(entry.Metadata) > 0 {
+		event.Metadata, _ = json.Marshal(entry.Metadata)
+	}
+
+	if _, err := service.AuditEventRepository.Create(event); err != nil {
+		log.Println("Failed to record audit event.", zap.String("event", entry.Event), zap.Error(err), zap.Time("timestamp", time.Now()))
+	}
+}
+
+// WithMobileHash adds a hash of the mobile keyed with AUDIT_HASH_KEY to the metadata, so the attempts on a mobile
+// can be told apart without the append-only log keeping the number. Nothing is added when the key is not configured.
+func WithMobileHash(metadata map[string]interface{}, mobile string) map[string]interface{} {
+	key := config.GetInstance().Get("AUDIT_HASH_KEY")
+	if key == "" || mobile == "" {
+		return metadata
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(mobile))
+	metadata["mobile_hash"] = hex.EncodeToString(mac.Sum(nil))
+	return metadata
+}
+
+// contextString returns the string stored under the key, or an empty string.
+func contextString(ctx context.Context, key string) string {
+	if ctx == nil {
+		return ""
+	}
+	value, _ := ctx.Value(key).(string)
+	return value
+}
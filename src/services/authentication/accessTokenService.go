@@ -1,13 +1,20 @@
 package authentication
 
 import (
+	"context"
 	"errors"
 	"github.com/google/uuid"
 	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/config"
 	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/geoip"
 	"go-auth-otp-service/src/hash"
 	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/useragent"
 	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -23,18 +30,26 @@ type IAccessTokenService interface {
 	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
 	GetActiveTokens(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
 	GetByUuid(accessTokenUuid *uuid.UUID) (*models.AccessTokenModel, error)
-	Create(owner interface{}, dto *JwtDTO, ip, userAgent string) (*models.AccessTokenModel, error)
+	Create(ctx context.Context, owner interface{}, dto *JwtDTO) (*models.AccessTokenModel, error)
 	UpdateLastUsedAt(accessToken *models.AccessTokenModel) (*models.AccessTokenModel, error)
-	RefreshAccessTokens(refreshToken, ownerType string) (*JwtDTO, error)
+	RefreshAccessTokens(ctx context.Context, refreshToken, ownerType string) (*JwtDTO, error)
 	Validate(tokenString string, tokenType TokenType, ownerType string) (*models.AccessTokenModel, error)
-	RevokeTokens(ownerID uint, ownerType string) error
-	RevokeTokenByUuid(accessTokenUuid *uuid.UUID, ownerID uint, ownerType string) error
+	ValidateWithClaims(tokenString string, tokenType TokenType, ownerType string) (*models.AccessTokenModel, *Claims, error)
+	RevokeTokens(ctx context.Context, ownerID uint, ownerType string) error
+	RevokeTokenByUuid(ctx context.Context, accessTokenUuid *uuid.UUID, ownerID uint, ownerType string) error
+	CheckOwnerIsActive(ownerID uint, ownerType string) error
+	PruneTokens(expiredFor, retention time.Duration, batchSize int) (int, error)
 }
 
 type AccessTokenService struct {
-	AccessTokenRepository repositories.IAccessTokenRepository
-	JwtService            IJwtService
-	UserRepository        repositories.IUserRepository
+	AccessTokenRepository   repositories.IAccessTokenRepository
+	TrustedDeviceRepository repositories.ITrustedDeviceRepository
+	JwtService              IJwtService
+	UserRepository          repositories.IUserRepository
+	AdminRepository         repositories.IAdminRepository
+	ClientRepository        repositories.IClientRepository
+	AuthorizationService    services.IAuthorizationService
+	AuditService            services.IAuditService
 }
 
 func (service *AccessTokenService) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
@@ -55,7 +70,13 @@ func (service *AccessTokenService) GetActiveTokens(builder *scopes.BuilderModel)
 	return res, nil
 }
 
-func (service *AccessTokenService) Create(owner interface{}, dto *JwtDTO, ip, userAgent string) (*models.AccessTokenModel, error) {
+// Create stores the tokens alongside the ip and device of the request found in the context.
+func (service *AccessTokenService) Create(ctx context.Context, owner interface{}, dto *JwtDTO) (*models.AccessTokenModel, error) {
+	ip, _ := ctx.Value("request-ip").(string)
+	userAgent, _ := ctx.Value("request-user-agent").(string)
+	device := useragent.Parse(userAgent)
+	location := geoip.GetInstance().Lookup(ip)
+
 	accessToken := &models.AccessTokenModel{
 		Uuid:                  dto.Uuid,
 		AccessToken:           []byte(dto.AccessTokenString),
@@ -64,18 +85,66 @@ func (service *AccessTokenService) Create(owner interface{}, dto *JwtDTO, ip, us
 		RefreshTokenExpiresAt: dto.RefreshTokenExpiresAt,
 		IP:                    ip,
 		UserAgent:             userAgent,
+		DeviceName:            device.Name(),
+		DeviceType:            device.DeviceType,
+		OS:                    device.OS,
+		OSVersion:             device.OSVersion,
+		Browser:               device.Browser,
+		BrowserVersion:        device.BrowserVersion,
+		CountryCode:           location.CountryCode,
+		Country:               location.Country,
+		City:                  location.City,
+		Scopes:                dto.Scopes,
+	}
+
+	// tokens requested with a DPoP proof can only be used with proofs of the same key
+	dto.TokenType = "Bearer"
+	if cnf := confirmation(ctx); cnf != nil {
+		accessToken.DPoPJkt = cnf.JKT
+		dto.TokenType = DPoPScheme
+	}
+
+	// the client may name the device and tell its platform and app version by itself
+	if name := headerValue(ctx, "request-device-name", 100); name != "" {
+		accessToken.DeviceName = name
+	}
+	accessToken.Platform = headerValue(ctx, "request-device-platform", 50)
+	if accessToken.Platform == "" && device.Browser != "" && device.DeviceType != useragent.Bot {
+		accessToken.Platform = "web"
 	}
+	accessToken.AppVersion = headerValue(ctx, "request-app-version", 50)
+
+	// a login verified by a second factor counts as a recent authentication
+	if dto.Acr != "" {
+		authTime := time.Now()
+		accessToken.AuthTime = &authTime
+		accessToken.Acr = dto.Acr
+	}
+
 	switch owner := owner.(type) {
 	case *models.UserModel:
 		accessToken.OwnerID = owner.ID
 		accessToken.OwnerType = "user"
+	case *models.AdminModel:
+		accessToken.OwnerID = owner.ID
+		accessToken.OwnerType = "admin"
+	case *models.ClientModel:
+		accessToken.OwnerID = owner.ID
+		accessToken.OwnerType = "client"
 	default:
 		return nil, errors.New("unsupported owner type")
 	}
 
+	// impersonation sessions are marked and never take a slot of the owner's own sessions
+	if dto.ImpersonatorID != 0 {
+		accessToken.ImpersonatorID = &dto.ImpersonatorID
+	} else if err := service.enforceSessionLimit(ctx, accessToken.OwnerID, accessToken.OwnerType); err != nil {
+		return nil, err
+	}
+
 	atOrm, err := service.AccessTokenRepository.Create(accessToken)
 	if err != nil {
-		return nil, err
+		return nil, errs.SomeThingWentWrong
 	}
 
 	return atOrm, nil
@@ -99,18 +168,58 @@ func (service *AccessTokenService) UpdateLastUsedAt(accessToken *models.AccessTo
 	return res, nil
 }
 
-func (service *AccessTokenService) RefreshAccessTokens(refreshToken, ownerType string) (*JwtDTO, error) {
+func (service *AccessTokenService) RefreshAccessTokens(ctx context.Context, refreshToken, ownerType string) (*JwtDTO, error) {
 	//validate token
 	token, err := service.Validate(refreshToken, RefreshToken, ownerType)
 	if err != nil {
+		service.AuditService.Record(ctx, services.AuditEntry{
+			Event:     services.AuditTokenRefreshed,
+			Outcome:   services.AuditFailure,
+			OwnerType: ownerType,
+		})
+		if err == errs.ErrUserIsNotActive || err == errs.ErrAdminIsNotActive || err == errs.ErrSessionExpired {
+			return nil, err
+		}
 		return nil, errs.ErrInvalidRefreshToken
 	}
 
+	// impersonation sessions end with their short lived tokens
+	if token.ImpersonatorID != nil {
+		return nil, errs.ErrInvalidRefreshToken
+	}
+
+	// a session bound to a DPoP key is only refreshed with a proof of the same key
+	cnf := confirmation(ctx)
+	if token.DPoPJkt != "" && (cnf == nil || cnf.JKT != token.DPoPJkt) {
+		return nil, errs.ErrInvalidDPoPProof
+	}
+
+	// reload the owner permissions so role changes apply on refresh
+	permissions, err := service.AuthorizationService.GetOwnerPermissions(token.OwnerID, token.OwnerType)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
 	// generate new jwt
-	jwtDto, err := service.JwtService.Generate()
+	customClaims := CustomClaims{OwnerType: token.OwnerType, Permissions: permissions}
+	if token.DPoPJkt != "" {
+		customClaims.Cnf = cnf
+	}
+	if token.OwnerType == "user" {
+		user, err := service.UserRepository.GetByID(token.OwnerID)
+		if err != nil {
+			return nil, errs.ErrUserIsNotActive
+		}
+		customClaims.UserUuid = user.Uuid.String()
+	}
+	jwtDto, err := service.JwtService.GenerateWithClaims(customClaims)
 	if err != nil {
 		return nil, errs.SomeThingWentWrong
 	}
+	jwtDto.TokenType = "Bearer"
+	if customClaims.Cnf != nil {
+		jwtDto.TokenType = DPoPScheme
+	}
 
 	// update user tokens
 	_, err = service.AccessTokenRepository.RefreshAccessTokens(
@@ -124,31 +233,46 @@ func (service *AccessTokenService) RefreshAccessTokens(refreshToken, ownerType s
 	if err != nil {
 		return nil, errs.SomeThingWentWrong
 	}
-
+	// refreshing counts as using the session for the idle timeout
+	_, _ = service.AccessTokenRepository.UpdateLastUsedAt(token, time.Now())
+
+	service.AuditService.Record(ctx, services.AuditEntry{
+		Event:     services.AuditTokenRefreshed,
+		Outcome:   services.AuditSuccess,
+		OwnerID:   token.OwnerID,
+		OwnerType: token.OwnerType,
+		Metadata:  map[string]interface{}{"token_uuid": jwtDto.Uuid.String()},
+	})
 	return jwtDto, nil
 }
 
 func (service *AccessTokenService) Validate(tokenString string, tokenType TokenType, ownerType string) (*models.AccessTokenModel, error) {
+	token, _, err := service.ValidateWithClaims(tokenString, tokenType, ownerType)
+	return token, err
+}
+
+// ValidateWithClaims validates the token like Validate and also returns the claims embedded in it.
+func (service *AccessTokenService) ValidateWithClaims(tokenString string, tokenType TokenType, ownerType string) (*models.AccessTokenModel, *Claims, error) {
 	// Validate the extracted JWT token and retrieve the user claims.
 	userClaimed, err := service.JwtService.Validate(tokenString)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Additionally, validate the token against the database and check for its expiry.
 	claimedUuid, err := uuid.Parse(userClaimed.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Retrieve the access token from the database using the parsed UUID.
 	token, err := service.AccessTokenRepository.GetByUuid(&claimedUuid)
 	if err != nil {
-		return nil, errs.RecordNotFound
+		return nil, nil, errs.RecordNotFound
 	}
 
 	if token.OwnerType != ownerType {
-		return nil, errs.ErrAuthenticationFailed
+		return nil, nil, errs.ErrAuthenticationFailed
 	}
 
 	// Verify the hash of the stored token against the provided token to ensure they match.
@@ -167,32 +291,63 @@ func (service *AccessTokenService) Validate(tokenString string, tokenType TokenT
 
 	hashCheck, err := hash.VerifyStoredHash(storedHash, tokenString)
 	if err != nil || !hashCheck {
-		return nil, errs.ErrAuthenticationFailed
+		return nil, nil, errs.ErrAuthenticationFailed
 	}
 
 	// Check if the token has expired by comparing its expiry timestamp against the current time.
 	if tokenExpiresAt.Before(time.Now()) {
-		return nil, errs.ErrTokenExpired
+		return nil, nil, errs.ErrTokenExpired
+	}
+
+	// The session itself may have outlived its idle or absolute lifetime while the token is still valid.
+	if sessionHasExpired(token, time.Now()) {
+		return nil, nil, errs.ErrSessionExpired
+	}
+
+	// Tokens of deactivated or deleted owners are no longer honoured.
+	if err = service.CheckOwnerIsActive(token.OwnerID, token.OwnerType); err != nil {
+		return nil, nil, err
 	}
-	return token, nil
+	return token, userClaimed, nil
 }
 
-func (service *AccessTokenService) RevokeTokens(ownerID uint, ownerType string) error {
+// RevokeTokens signs the owner out everywhere, the trusted devices of a user are revoked too so none of them
+// can sign back in without an otp.
+func (service *AccessTokenService) RevokeTokens(ctx context.Context, ownerID uint, ownerType string) error {
+	if ownerType == "user" {
+		if err := service.TrustedDeviceRepository.DeleteAll(ownerID); err != nil {
+			return errs.SomeThingWentWrong
+		}
+	}
+
 	// get list of tokens
 	accessTokens, err := service.AccessTokenRepository.GetAll(ownerID, ownerType)
 	if err != nil {
 		return errs.SomeThingWentWrong
 	}
 
+	// nothing to revoke, deleting an empty slice has no where clause and is refused
+	if len(accessTokens) == 0 {
+		return nil
+	}
+
 	// delete the tokens
 	err = service.AccessTokenRepository.DeleteMany(accessTokens)
 	if err != nil {
 		return errs.SomeThingWentWrong
 	}
+
+	service.AuditService.Record(ctx, services.AuditEntry{
+		Event:     services.AuditTokensRevoked,
+		Outcome:   services.AuditSuccess,
+		OwnerID:   ownerID,
+		OwnerType: ownerType,
+		Metadata:  map[string]interface{}{"count": len(accessTokens)},
+	})
 	return nil
 }
 
-func (service *AccessTokenService) RevokeTokenByUuid(accessTokenUuid *uuid.UUID, ownerID uint, ownerType string) error {
+func (service *AccessTokenService) RevokeTokenByUuid(ctx context.Context, accessTokenUuid *uuid.UUID, ownerID uint, ownerType string) error {
 	accessToken, err := service.AccessTokenRepository.GetByUuid(accessTokenUuid)
 	if err != nil {
 		return errs.RecordNotFound
@@ -206,5 +361,145 @@ func (service *AccessTokenService) RevokeTokenByUuid(accessTokenUuid *uuid.UUID,
 	if err != nil {
 		return errs.SomeThingWentWrong
 	}
+
+	// the device trusted by this session goes with it
+	if err = service.TrustedDeviceRepository.DeleteByAccessToken(accessToken.ID); err != nil {
+		return errs.SomeThingWentWrong
+	}
+
+	service.AuditService.Record(ctx, services.AuditEntry{
+		Event:     services.AuditTokenRevoked,
+		Outcome:   services.AuditSuccess,
+		OwnerID:   ownerID,
+		OwnerType: ownerType,
+		Metadata:  map[string]interface{}{"token_uuid": accessToken.Uuid.String()},
+	})
 	return nil
 }
+
+// CheckOwnerIsActive makes sure the owner of a token still exists and is active.
+// Soft-deleted owners are not found by the repositories and are rejected as well.
+func (service *AccessTokenService) CheckOwnerIsActive(ownerID uint, ownerType string) error {
+	switch ownerType {
+	case "user":
+		user, err := service.UserRepository.GetByID(ownerID)
+		if err != nil || !user.IsActive {
+			return errs.ErrUserIsNotActive
+		}
+	case "admin":
+		admin, err := service.AdminRepository.GetByID(ownerID)
+		if err != nil || !admin.IsActive {
+			return errs.ErrAdminIsNotActive
+		}
+	case "client":
+		client, err := service.ClientRepository.GetByID(ownerID)
+		if err != nil || !client.IsActive {
+			return errs.ErrInvalidClient
+		}
+	default:
+		return errs.ErrAuthenticationFailed
+	}
+
+	return nil
+}
+
+// headerValue returns the client supplied value stored in the context, cut to the column size.
+func headerValue(ctx context.Context, key string, size int) string {
+	value, _ := ctx.Value(key).(string)
+	value = strings.TrimSpace(value)
+	if len(value) > size {
+		value = strings.ToValidUTF8(value[:size], "")
+	}
+	return value
+}
+
+// PruneTokens hard deletes the tokens whose refresh token expired longer than expiredFor ago
+// and the revoked tokens kept longer than retention, batchSize rows at a time so the table is never locked for long.
+// It returns the number of deleted tokens.
+func (service *AccessTokenService) PruneTokens(expiredFor, retention time.Duration, batchSize int) (int, error) {
+	pruned := 0
+	for {
+		now := time.Now()
+		count, err := service.AccessTokenRepository.Prune(now.Add(-expiredFor), now.Add(-retention), batchSize)
+		if err != nil {
+			return pruned, err
+		}
+
+		pruned += int(count)
+		if count < int64(batchSize) {
+			return pruned, nil
+		}
+	}
+}
+
+// enforceSessionLimit makes room for a new session of the owner under SESSION_LIMIT_<OWNER TYPE>.
+// With the "reject" SESSION_LIMIT_POLICY the login is refused, otherwise the least recently used sessions are revoked.
+func (service *AccessTokenService) enforceSessionLimit(ctx context.Context, ownerID uint, ownerType string) error {
+	configs := config.GetInstance()
+	limit, err := strconv.Atoi(configs.Get("SESSION_LIMIT_" + strings.ToUpper(ownerType)))
+	if err != nil || limit <= 0 {
+		return nil
+	}
+
+	sessions, err := service.AccessTokenRepository.GetUnexpired(ownerID, ownerType)
+	if err != nil {
+		return errs.SomeThingWentWrong
+	}
+	if len(sessions) < limit {
+		return nil
+	}
+
+	if configs.Get("SESSION_LIMIT_POLICY") == "reject" {
+		service.AuditService.Record(ctx, services.AuditEntry{
+			Event:     services.AuditLogin,
+			Outcome:   services.AuditFailure,
+			OwnerID:   ownerID,
+			OwnerType: ownerType,
+			Metadata:  map[string]interface{}{"reason": "session-limit"},
+		})
+		return errs.ErrSessionLimitReached
+	}
+
+	// sessions are sorted by their last use, the oldest are evicted
+	evicted := sessions[:len(sessions)-limit+1]
+	if err = service.AccessTokenRepository.DeleteMany(evicted); err != nil {
+		return errs.SomeThingWentWrong
+	}
+
+	for _, session := range evicted {
+		service.AuditService.Record(ctx, services.AuditEntry{
+			Event:     services.AuditTokenRevoked,
+			Outcome:   services.AuditSuccess,
+			OwnerID:   ownerID,
+			OwnerType: ownerType,
+			Metadata:  map[string]interface{}{"session": session.Uuid, "reason": "session-limit"},
+		})
+	}
+	return nil
+}
+
+// sessionHasExpired applies the SESSION_IDLE_TIMEOUT_<OWNER TYPE> and SESSION_ABSOLUTE_LIFETIME_<OWNER TYPE> minutes,
+// a session not used for the idle timeout or opened longer than the absolute lifetime ago is over whatever its tokens say.
+func sessionHasExpired(token *models.AccessTokenModel, now time.Time) bool {
+	lastActivity := token.CreatedAt
+	if token.LastUsedAt != nil && token.LastUsedAt.After(lastActivity) {
+		lastActivity = *token.LastUsedAt
+	}
+
+	if idle := sessionTimeout("SESSION_IDLE_TIMEOUT_", token.OwnerType); idle > 0 && now.Sub(lastActivity) > idle {
+		return true
+	}
+	if lifetime := sessionTimeout("SESSION_ABSOLUTE_LIFETIME_", token.OwnerType); lifetime > 0 && now.Sub(token.CreatedAt) > lifetime {
+		return true
+	}
+	return false
+}
+
+// sessionTimeout reads the minutes configured for the owner type, zero when not set.
+func sessionTimeout(prefix, ownerType string) time.Duration {
+	minutes, err := strconv.Atoi(config.GetInstance().Get(prefix + strings.ToUpper(ownerType)))
+	if err != nil || minutes <= 0 {
+		return 0
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,301 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/requests/adminRequests"
+	"go-auth-otp-service/src/cache"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/hash"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/totp"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+	"strconv"
+	"time"
+)
+
+const (
+	AdminLoginMethodOTP  = "otp"
+	AdminLoginMethodTOTP = "totp"
+)
+
+// adminLoginMaxAttempts is the number of wrong codes after which a started login is dropped.
+const adminLoginMaxAttempts = 5
+
+type IAdminAuthenticationService interface {
+	Login(ctx context.Context, req *adminRequests.LoginRequest) (key string, method string, err error)
+	VerifyLogin(ctx context.Context, req *adminRequests.VerifyLoginRequest) (*JwtDTO, error)
+	GetByID(id uint) (*models.AdminModel, error)
+	SetupTotp(adminID uint) (secret string, url string, err error)
+	EnableTotp(adminID uint, code string) error
+}
+
+type AdminAuthenticationService struct {
+	AdminRepository      repositories.IAdminRepository
+	OTPService           services.IOTPService
+	AccessTokenService   IAccessTokenService
+	JwtService           IJwtService
+	AuthorizationService services.IAuthorizationService
+	AuditService         services.IAuditService
+}
+
+// adminLoginState is kept in redis between the password and the code verification steps.
+type adminLoginState struct {
+	AdminID uint   `json:"admin_id"`
+	Method  string `json:"method"`
+}
+
+// Login verifies the admin password and starts the second factor challenge.
+// Admins with an enabled totp are challenged for it, the others receive an otp on their mobile.
+func (service *AdminAuthenticationService) Login(ctx context.Context, req *adminRequests.LoginRequest) (string, string, error) {
+	admin, err := service.AdminRepository.GetByUsername(req.Username)
+	if err != nil {
+		service.AuditService.Record(ctx, services.AuditEntry{
+			Event:     services.AuditPasswordChecked,
+			Outcome:   services.AuditFailure,
+			OwnerType: "admin",
+			Metadata:  map[string]interface{}{"username": req.Username},
+		})
+		return "", "", errs.ErrInvalidCredentials
+	}
+
+	passwordCheck, err := hash.VerifyStoredHash(admin.Password, req.Password)
+	if err != nil || !passwordCheck {
+		service.AuditService.Record(ctx, services.AuditEntry{
+			Event:     services.AuditPasswordChecked,
+			Outcome:   services.AuditFailure,
+			OwnerID:   admin.ID,
+			OwnerType: "admin",
+		})
+		return "", "", errs.ErrInvalidCredentials
+	}
+
+	if !admin.IsActive {
+		return "", "", errs.ErrAdminIsNotActive
+	}
+
+	service.AuditService.Record(ctx, services.AuditEntry{
+		Event:     services.AuditPasswordChecked,
+		Outcome:   services.AuditSuccess,
+		OwnerID:   admin.ID,
+		OwnerType: "admin",
+	})
+
+	state := adminLoginState{AdminID: admin.ID, Method: AdminLoginMethodOTP}
+	if admin.TotpEnabled {
+		state.Method = AdminLoginMethodTOTP
+	} else if err = service.OTPService.RequestOTP(admin.Mobile); err != nil {
+		return "", "", err
+	}
+
+	// marshal the state to save in redis
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return "", "", errs.SomeThingWentWrong
+	}
+
+	// get expire time
+	expiration, err := strconv.Atoi(config.GetInstance().Get("REGISTER_SAVE_STATE_LIFETIME"))
+	if err != nil {
+		expiration = 120
+	}
+
+	key := uuid.New().String()
+	err = cache.GetInstance().GetClient().Set(context.Background(), getAdminLoginRedisKey(key), stateData, time.Duration(expiration)*time.Second).Err()
+	if err != nil {
+		return "", "", errs.SomeThingWentWrong
+	}
+
+	return key, state.Method, nil
+}
+
+// VerifyLogin checks the second factor of a started login and issues the admin tokens.
+func (service *AdminAuthenticationService) VerifyLogin(ctx context.Context, req *adminRequests.VerifyLoginRequest) (*JwtDTO, error) {
+	res, err := cache.GetInstance().GetClient().Get(context.Background(), getAdminLoginRedisKey(req.Key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, errs.ErrAuthenticationFailed
+	} else if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	var state adminLoginState
+	if err = json.Unmarshal([]byte(res), &state); err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	admin, err := service.AdminRepository.GetByID(state.AdminID)
+	if err != nil {
+		return nil, errs.ErrAuthenticationFailed
+	}
+
+	if !admin.IsActive {
+		return nil, errs.ErrAdminIsNotActive
+	}
+
+	// verify the second factor
+	loginFailed := services.AuditEntry{
+		Event:     services.AuditLogin,
+		Outcome:   services.AuditFailure,
+		OwnerID:   admin.ID,
+		OwnerType: "admin",
+		Metadata:  map[string]interface{}{"method": state.Method},
+	}
+	switch state.Method {
+	case AdminLoginMethodTOTP:
+		if !useTotpCode(service.AdminRepository, admin, req.Code) {
+			service.AuditService.Record(ctx, loginFailed)
+			failLoginAttempt(req.Key)
+			return nil, errs.ErrInvalid2FACode
+		}
+	default:
+		otpIsValid, err := service.OTPService.VerifyOTP(admin.Mobile, req.Code)
+		if err != nil {
+			return nil, errs.SomeThingWentWrong
+		}
+		if !otpIsValid {
+			service.AuditService.Record(ctx, loginFailed)
+			failLoginAttempt(req.Key)
+			return nil, errs.ErrOTPInvalid
+		}
+	}
+
+	// the login state can only be used once
+	_ = cache.GetInstance().GetClient().Del(context.Background(), getAdminLoginRedisKey(req.Key), getAdminLoginAttemptsRedisKey(req.Key)).Err()
+
+	// get the admin permissions to embed in the token
+	permissions, err := service.AuthorizationService.GetOwnerPermissions(admin.ID, "admin")
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	// generate token
+	jwtDTO, err := service.JwtService.GenerateWithClaims(CustomClaims{OwnerType: "admin", Permissions: permissions, Cnf: confirmation(ctx)})
+	if err != nil {
+		return nil, errs.ErrAuthenticationFailed
+	}
+	jwtDTO.Acr = state.Method
+
+	// Store tokens in database
+	_, err = service.AccessTokenService.Create(ctx, admin, jwtDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	service.AuditService.Record(ctx, services.AuditEntry{
+		Event:     services.AuditLogin,
+		Outcome:   services.AuditSuccess,
+		OwnerID:   admin.ID,
+		OwnerType: "admin",
+		Metadata:  map[string]interface{}{"method": state.Method},
+	})
+	return jwtDTO, nil
+}
+
+func (service *AdminAuthenticationService) GetByID(id uint) (*models.AdminModel, error) {
+	admin, err := service.AdminRepository.GetByID(id)
+	if err != nil {
+		return nil, errs.RecordNotFound
+	}
+
+	return admin, nil
+}
+
+// SetupTotp generates a pending totp secret for the admin, it is only used once confirmed by EnableTotp.
+func (service *AdminAuthenticationService) SetupTotp(adminID uint) (string, string, error) {
+	admin, err := service.AdminRepository.GetByID(adminID)
+	if err != nil {
+		return "", "", errs.RecordNotFound
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", errs.SomeThingWentWrong
+	}
+
+	err = cache.GetInstance().GetClient().Set(context.Background(), getAdminTotpSetupRedisKey(admin.ID), secret, 10*time.Minute).Err()
+	if err != nil {
+		return "", "", errs.SomeThingWentWrong
+	}
+
+	return secret, totp.URL(config.GetInstance().Get("APP_NAME"), admin.Username, secret), nil
+}
+
+// EnableTotp confirms the pending totp secret with a code and enables it for the admin logins.
+func (service *AdminAuthenticationService) EnableTotp(adminID uint, code string) error {
+	secret, err := cache.GetInstance().GetClient().Get(context.Background(), getAdminTotpSetupRedisKey(adminID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return errs.ErrTwoFactorNotActive
+	} else if err != nil {
+		return errs.SomeThingWentWrong
+	}
+
+	step, ok := totp.ValidateStep(secret, code, 0)
+	if !ok {
+		return errs.ErrInvalid2FACode
+	}
+
+	admin, err := service.AdminRepository.GetByID(adminID)
+	if err != nil {
+		return errs.RecordNotFound
+	}
+
+	admin.TotpSecret = secret
+	admin.TotpEnabled = true
+	admin.TotpLastStep = step
+	if _, err = service.AdminRepository.Update(admin); err != nil {
+		return errs.SomeThingWentWrong
+	}
+
+	_ = cache.GetInstance().GetClient().Del(context.Background(), getAdminTotpSetupRedisKey(adminID)).Err()
+	return nil
+}
+
+// useTotpCode checks the totp code of the admin and consumes its time step, so it can not be replayed.
+func useTotpCode(adminRepository repositories.IAdminRepository, admin *models.AdminModel, code string) bool {
+	step, ok := totp.ValidateStep(admin.TotpSecret, code, admin.TotpLastStep)
+	if !ok {
+		return false
+	}
+
+	used, err := adminRepository.UseTotpStep(admin.ID, step)
+	return err == nil && used
+}
+
+// failLoginAttempt counts a wrong code of a started login and drops the login once it had too many.
+func failLoginAttempt(key string) {
+	client := cache.GetInstance().GetClient()
+	attempts, err := client.Incr(context.Background(), getAdminLoginAttemptsRedisKey(key)).Result()
+	if err != nil {
+		return
+	}
+
+	if attempts == 1 {
+		// the counter lives as long as the login state
+		ttl, _ := client.TTL(context.Background(), getAdminLoginRedisKey(key)).Result()
+		if ttl <= 0 {
+			ttl = 10 * time.Minute
+		}
+		_ = client.Expire(context.Background(), getAdminLoginAttemptsRedisKey(key), ttl).Err()
+	}
+
+	if attempts >= adminLoginMaxAttempts {
+		_ = client.Del(context.Background(), getAdminLoginRedisKey(key), getAdminLoginAttemptsRedisKey(key)).Err()
+	}
+}
+
+func getAdminLoginRedisKey(key string) string {
+	return "admin-login-" + key
+}
+
+func getAdminLoginAttemptsRedisKey(key string) string {
+	return "admin-login-attempts-" + key
+}
+
+func getAdminTotpSetupRedisKey(adminID uint) string {
+	return "admin-totp-setup-" + strconv.FormatUint(uint64(adminID), 10)
+}
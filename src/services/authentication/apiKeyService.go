@@ -0,0 +1,134 @@
+package authentication
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/requests/authentication"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/hash"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/utils"
+	"go-auth-otp-service/src/repositories"
+	"strings"
+	"time"
+)
+
+// ApiKeyPrefix makes personal access tokens recognisable, both for the
+// authentication middleware and for secret scanners.
+const ApiKeyPrefix = "pat_"
+
+type IApiKeyService interface {
+	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	Create(ownerID uint, ownerType string, req *authentication.CreateApiKeyRequest) (*models.ApiKeyModel, string, error)
+	Validate(key string, ownerType string) (*models.ApiKeyModel, error)
+	UpdateLastUsedAt(apiKey *models.ApiKeyModel) (*models.ApiKeyModel, error)
+	Revoke(apiKeyUuid *uuid.UUID, ownerID uint, ownerType string) error
+	RevokeAll(ownerID uint, ownerType string) error
+}
+
+type ApiKeyService struct {
+	ApiKeyRepository repositories.IApiKeyRepository
+}
+
+func (service *ApiKeyService) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	res, err := service.ApiKeyRepository.GetList(builder)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return res, nil
+}
+
+// Create generates a new api key for the owner and returns it alongside the plain key.
+// The plain key has the form pat_<key-id>_<secret> and is never stored.
+func (service *ApiKeyService) Create(ownerID uint, ownerType string, req *authentication.CreateApiKeyRequest) (*models.ApiKeyModel, string, error) {
+	keyID, err := utils.GenerateSalt(8)
+	if err != nil {
+		return nil, "", errs.SomeThingWentWrong
+	}
+
+	secret, err := utils.GenerateSalt(32)
+	if err != nil {
+		return nil, "", errs.SomeThingWentWrong
+	}
+	secretString := base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey, err := service.ApiKeyRepository.Create(&models.ApiKeyModel{
+		Uuid:      uuid.New(),
+		OwnerID:   ownerID,
+		OwnerType: ownerType,
+		Name:      req.Name,
+		KeyID:     hex.EncodeToString(keyID),
+		Key:       []byte(secretString),
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, "", errs.SomeThingWentWrong
+	}
+
+	return apiKey, fmt.Sprintf("%s%s_%s", ApiKeyPrefix, apiKey.KeyID, secretString), nil
+}
+
+// Validate looks the api key up by its key id and verifies the secret against the stored hash.
+func (service *ApiKeyService) Validate(key string, ownerType string) (*models.ApiKeyModel, error) {
+	parts := strings.SplitN(strings.TrimPrefix(key, ApiKeyPrefix), "_", 2)
+	if !strings.HasPrefix(key, ApiKeyPrefix) || len(parts) != 2 {
+		return nil, errs.ErrInvalidToken
+	}
+
+	apiKey, err := service.ApiKeyRepository.GetByKeyID(parts[0])
+	if err != nil {
+		return nil, errs.ErrInvalidToken
+	}
+
+	if apiKey.OwnerType != ownerType {
+		return nil, errs.ErrAuthenticationFailed
+	}
+
+	hashCheck, err := hash.VerifyStoredHash(apiKey.Key, parts[1])
+	if err != nil || !hashCheck {
+		return nil, errs.ErrAuthenticationFailed
+	}
+
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(time.Now()) {
+		return nil, errs.ErrTokenExpired
+	}
+	return apiKey, nil
+}
+
+func (service *ApiKeyService) UpdateLastUsedAt(apiKey *models.ApiKeyModel) (*models.ApiKeyModel, error) {
+	res, err := service.ApiKeyRepository.UpdateLastUsedAt(apiKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (service *ApiKeyService) Revoke(apiKeyUuid *uuid.UUID, ownerID uint, ownerType string) error {
+	apiKey, err := service.ApiKeyRepository.GetByUuid(apiKeyUuid)
+	if err != nil {
+		return errs.RecordNotFound
+	}
+
+	if apiKey.OwnerID != ownerID || apiKey.OwnerType != ownerType {
+		return errs.RecordNotFound
+	}
+
+	err = service.ApiKeyRepository.Delete(apiKey)
+	if err != nil {
+		return errs.SomeThingWentWrong
+	}
+	return nil
+}
+
+func (service *ApiKeyService) RevokeAll(ownerID uint, ownerType string) error {
+	if err := service.ApiKeyRepository.DeleteAll(ownerID, ownerType); err != nil {
+		return errs.SomeThingWentWrong
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"go-auth-otp-service/src/api/http/requests/userRequests"
 	"go-auth-otp-service/src/cache"
 	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/models"
 	"go-auth-otp-service/src/services"
 	"gorm.io/gorm"
 	"strconv"
@@ -17,18 +18,38 @@ import (
 )
 
 type RegisterService struct {
-	UserService        services.IUserService
-	OTPService         services.IOTPService
-	AccessTokenService IAccessTokenService
-	JwtService         IJwtService
+	UserService          services.IUserService
+	OTPService           services.IOTPService
+	AccessTokenService   IAccessTokenService
+	JwtService           IJwtService
+	AuthorizationService services.IAuthorizationService
+	AuditService         services.IAuditService
+	DeviceService        IDeviceService
+	TrustedDeviceService ITrustedDeviceService
 }
 
 type IRegisterService interface {
-	SaveStateAndSendOTP(req *authentication.AuthSendOtpRequest) (string, error)
+	SaveStateAndSendOTP(ctx context.Context, req *authentication.AuthSendOtpRequest) (string, error)
 	VerifyRegisterOTPViaRedisKey(ctx context.Context, req *authentication.AuthVerifyOTP) (*JwtDTO, error)
 }
 
-func (service *RegisterService) SaveStateAndSendOTP(req *authentication.AuthSendOtpRequest) (string, error) {
+func (service *RegisterService) SaveStateAndSendOTP(ctx context.Context, req *authentication.AuthSendOtpRequest) (string, error) {
+	// deactivated and deleted users can not receive an otp
+	user, err := service.checkUserIsActive(req.Mobile)
+	audit := services.AuditEntry{
+		Event:     services.AuditOtpRequested,
+		Outcome:   services.AuditFailure,
+		OwnerType: "user",
+		Metadata:  services.WithMobileHash(map[string]interface{}{}, req.Mobile),
+	}
+	if user != nil {
+		audit.OwnerID = user.ID
+	}
+	if err != nil {
+		service.AuditService.Record(ctx, audit)
+		return "", err
+	}
+
 	// marshal the req to save in redis
 	reqData, err := json.Marshal(req)
 	if err != nil {
@@ -49,9 +70,12 @@ func (service *RegisterService) SaveStateAndSendOTP(req *authentication.AuthSend
 	}
 	err = service.OTPService.RequestOTP(req.Mobile)
 	if err != nil {
+		service.AuditService.Record(ctx, audit)
 		return "", err
 	}
 
+	audit.Outcome = services.AuditSuccess
+	service.AuditService.Record(ctx, audit)
 	return key, nil
 }
 
@@ -70,6 +94,20 @@ func (service *RegisterService) VerifyRegisterOTPViaRedisKey(ctx context.Context
 		return nil, errs.SomeThingWentWrong
 	}
 
+	user, err := service.UserService.GetByMobileWithTrashed(resp.Mobile)
+	if err != nil && err.Error() != gorm.ErrRecordNotFound.Error() {
+		return nil, errs.SomeThingWentWrong
+	}
+	audit := services.AuditEntry{
+		Event:     services.AuditOtpFailed,
+		Outcome:   services.AuditFailure,
+		OwnerType: "user",
+		Metadata:  services.WithMobileHash(map[string]interface{}{}, resp.Mobile),
+	}
+	if user != nil {
+		audit.OwnerID = user.ID
+	}
+
 	var otpIsValid bool
 	otpIsValid, err = service.OTPService.VerifyOTP(resp.Mobile, req.OTP)
 	if err != nil {
@@ -77,11 +115,15 @@ func (service *RegisterService) VerifyRegisterOTPViaRedisKey(ctx context.Context
 	}
 
 	if !otpIsValid {
+		service.AuditService.Record(ctx, audit)
 		return nil, errs.ErrOTPInvalid
 	}
-	user, err := service.UserService.GetByMobile(resp.Mobile)
-	if err != nil && err.Error() != gorm.ErrRecordNotFound.Error() {
-		return nil, errs.SomeThingWentWrong
+
+	// the user may have been deactivated after the otp was sent
+	if user != nil && (!user.IsActive || user.DeletedAt.Valid) {
+		audit.Event = services.AuditLogin
+		service.AuditService.Record(ctx, audit)
+		return nil, errs.ErrUserIsNotActive
 	}
 	//register user if not exists
 	if user == nil {
@@ -93,20 +135,82 @@ func (service *RegisterService) VerifyRegisterOTPViaRedisKey(ctx context.Context
 		if err != nil {
 			return nil, errs.SomeThingWentWrong
 		}
+
+		service.AuditService.Record(ctx, services.AuditEntry{
+			Event:     services.AuditUserRegistered,
+			Outcome:   services.AuditSuccess,
+			OwnerID:   user.ID,
+			OwnerType: "user",
+		})
+	}
+	service.AuditService.Record(ctx, services.AuditEntry{
+		Event:     services.AuditOtpVerified,
+		Outcome:   services.AuditSuccess,
+		OwnerID:   user.ID,
+		OwnerType: "user",
+	})
+	// get the user permissions to embed in the token
+	permissions, err := service.AuthorizationService.GetOwnerPermissions(user.ID, "user")
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
 	}
+
 	//generate token
-	jwtDTO, err := service.JwtService.Generate()
+	jwtDTO, err := service.JwtService.GenerateWithClaims(CustomClaims{OwnerType: "user", Permissions: permissions, Cnf: confirmation(ctx), UserUuid: user.Uuid.String()})
 	if err != nil {
 		return nil, errs.ErrAuthenticationFailed
 	}
+	jwtDTO.Acr = AcrOTP
 
 	// Store tokens in database
 	ip := ctx.Value("request-ip").(string)
 	userAgent := ctx.Value("request-user-agent").(string)
 
-	_, err = service.AccessTokenService.Create(user, jwtDTO, ip, userAgent)
+	// the device must be looked up before the new session joins the history
+	isNewDevice, err := service.DeviceService.IsNewDevice(user.ID, "user", ip, userAgent)
 	if err != nil {
-		return nil, errs.SomeThingWentWrong
+		return nil, err
+	}
+
+	accessToken, err := service.AccessTokenService.Create(ctx, user, jwtDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	if isNewDevice {
+		go service.DeviceService.NotifyNewDevice(ctx, user, accessToken)
+	}
+
+	// remember the device when asked, so it can skip the otp next time
+	if req.RememberDevice {
+		jwtDTO.TrustedDeviceToken, err = service.TrustedDeviceService.Trust(ctx, user, req.DeviceID, accessToken)
+		if err != nil {
+			return nil, err
+		}
 	}
+
+	service.AuditService.Record(ctx, services.AuditEntry{
+		Event:     services.AuditLogin,
+		Outcome:   services.AuditSuccess,
+		OwnerID:   user.ID,
+		OwnerType: "user",
+	})
 	return jwtDTO, nil
 }
+
+// checkUserIsActive returns the user owning the mobile and rejects deactivated or soft-deleted users,
+// unknown mobiles pass with a nil user to be registered.
+func (service *RegisterService) checkUserIsActive(mobile string) (*models.UserModel, error) {
+	user, err := service.UserService.GetByMobileWithTrashed(mobile)
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, nil
+		}
+		return nil, errs.SomeThingWentWrong
+	}
+
+	if !user.IsActive || user.DeletedAt.Valid {
+		return user, errs.ErrUserIsNotActive
+	}
+	return user, nil
+}
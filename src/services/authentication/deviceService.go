@@ -0,0 +1,178 @@
+package authentication
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/geoip"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/notifications"
+	"go-auth-otp-service/src/notifications/channels"
+	"go-auth-otp-service/src/pkg/i18n"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+	"go.uber.org/zap"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMissingDeviceAlertSigningKey is returned when revoke links are signed or verified without a key,
+// any link signed with an empty key could be forged.
+var ErrMissingDeviceAlertSigningKey = errors.New("DEVICE_ALERT_SIGNING_KEY is required to sign revoke links")
+
+type IDeviceService interface {
+	IsNewDevice(ownerID uint, ownerType, ip, userAgent string) (bool, error)
+	NotifyNewDevice(ctx context.Context, user *models.UserModel, accessToken *models.AccessTokenModel)
+	CheckRevokeLink(accessTokenID, expires, signature string) error
+	RevokeFromLink(ctx context.Context, accessTokenID, expires, signature string) error
+}
+
+// DeviceService recognises the devices an owner signs in from by the ip and user agent of their past sessions,
+// which are remembered as known devices so pruning the sessions does not forget them.
+type DeviceService struct {
+	AccessTokenRepository   repositories.IAccessTokenRepository
+	TrustedDeviceRepository repositories.ITrustedDeviceRepository
+	AccessTokenService      IAccessTokenService
+	AuditService            services.IAuditService
+}
+
+// IsNewDevice reports whether the owner has signed in before but never from the ip and user agent,
+// the very first sign in of an owner is not considered a new device.
+func (service *DeviceService) IsNewDevice(ownerID uint, ownerType, ip, userAgent string) (bool, error) {
+	count, err := service.AccessTokenRepository.CountDevices(ownerID, ownerType)
+	if err != nil {
+		return false, errs.SomeThingWentWrong
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	known, err := service.AccessTokenRepository.HasDevice(ownerID, ownerType, ip, userAgent)
+	if err != nil {
+		return false, errs.SomeThingWentWrong
+	}
+	return !known, nil
+}
+
+// NotifyNewDevice tells the user through their channels that the session was opened from a new device,
+// the message carries a link which revokes the session in one click.
+// Failing to notify never fails the sign in, the error is logged instead.
+func (service *DeviceService) NotifyNewDevice(ctx context.Context, user *models.UserModel, accessToken *models.AccessTokenModel) {
+	service.AuditService.Record(ctx, services.AuditEntry{
+		Event:     services.AuditNewDeviceLogin,
+		Outcome:   services.AuditSuccess,
+		OwnerID:   user.ID,
+		OwnerType: "user",
+		Metadata:  map[string]interface{}{"session": accessToken.Uuid},
+	})
+
+	device := accessToken.DeviceName
+	if device == "" {
+		device = accessToken.UserAgent
+	}
+
+	location := accessToken.IP
+	if place := (geoip.Location{Country: accessToken.Country, City: accessToken.City}).String(); place != "" {
+		location = place + " (" + accessToken.IP + ")"
+	}
+
+	link, err := service.revokeLink(accessToken)
+	if err != nil {
+		log.Println("Failed to notify new device.", zap.Uint("user", user.ID), zap.Error(err), zap.Time("timestamp", time.Now()))
+		return
+	}
+
+	locale, _ := ctx.Value("locale").(string)
+	message := channels.Message{
+		Subject: i18n.Localize(locale, "new-device-login-subject"),
+		Body: i18n.Localize(locale, "new-device-login-body", map[string]interface{}{
+			"Device":   device,
+			"Location": location,
+			"Time":     accessToken.CreatedAt.Format(time.RFC1123),
+			"Link":     link,
+		}),
+	}
+
+	recipient := channels.Recipient{Mobile: user.Mobile, Email: user.Email}
+	if err := notifications.GetInstance().Send(context.Background(), recipient, message); err != nil {
+		log.Println("Failed to notify new device.", zap.Uint("user", user.ID), zap.Error(err), zap.Time("timestamp", time.Now()))
+	}
+}
+
+// CheckRevokeLink verifies the signature and expiry of a link sent by NotifyNewDevice without revoking anything.
+func (service *DeviceService) CheckRevokeLink(accessTokenID, expires, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return errs.ErrInvalidSignedLink
+	}
+	expected, err := signRevokeLink(accessTokenID, expires)
+	if err != nil || !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errs.ErrInvalidSignedLink
+	}
+	return nil
+}
+
+// RevokeFromLink verifies a link sent by NotifyNewDevice and revokes the session it points to
+// alongside the device it trusted, so the device can not sign back in without an otp.
+func (service *DeviceService) RevokeFromLink(ctx context.Context, accessTokenID, expires, signature string) error {
+	if err := service.CheckRevokeLink(accessTokenID, expires, signature); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(accessTokenID, 10, 64)
+	if err != nil {
+		return errs.ErrInvalidSignedLink
+	}
+
+	// the trusted device outlives its session, it is revoked even when the session is gone already
+	if err = service.TrustedDeviceRepository.DeleteByAccessToken(uint(id)); err != nil {
+		return errs.SomeThingWentWrong
+	}
+
+	// the session may have been revoked already
+	accessToken, err := service.AccessTokenRepository.GetByID(uint(id))
+	if err != nil {
+		return nil
+	}
+
+	return service.AccessTokenService.RevokeTokenByUuid(ctx, &accessToken.Uuid, accessToken.OwnerID, accessToken.OwnerType)
+}
+
+// revokeLink builds the signed link to revoke the session, it lives as long as the refresh token.
+// The session id is signed rather than its uuid since the uuid changes on every refresh.
+func (service *DeviceService) revokeLink(accessToken *models.AccessTokenModel) (string, error) {
+	id := strconv.FormatUint(uint64(accessToken.ID), 10)
+	expires := strconv.FormatInt(accessToken.RefreshTokenExpiresAt.Unix(), 10)
+
+	signature, err := signRevokeLink(id, expires)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", signature)
+
+	return fmt.Sprintf("%s/api/v1/authentication/sessions/%s/not-me?%s",
+		strings.TrimRight(config.GetInstance().Get("APP_URL"), "/"), id, query.Encode()), nil
+}
+
+// signRevokeLink returns the hmac of the session id and expiry, it refuses to sign without a key.
+func signRevokeLink(accessTokenID, expires string) (string, error) {
+	key := config.GetInstance().Get("DEVICE_ALERT_SIGNING_KEY")
+	if key == "" {
+		return "", ErrMissingDeviceAlertSigningKey
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(accessTokenID + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,89 @@
+package authentication
+
+import (
+	"context"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/cache"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/pkg/dpop"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DPoPScheme is the authorization scheme, and token type, of the access tokens bound to a DPoP key.
+const DPoPScheme = "DPoP"
+
+type IDPoPService interface {
+	Verify(proof, method, path, accessToken string) (string, error)
+}
+
+// DPoPService checks the DPoP proofs sent with the token requests and the bound access tokens.
+type DPoPService struct{}
+
+// Verify checks the proof was signed for this request within DPOP_PROOF_LIFETIME seconds and never used before,
+// and returns the thumbprint of its key. The proof must hash the access token when one is given.
+func (service *DPoPService) Verify(proof, method, path, accessToken string) (string, error) {
+	parsed, err := dpop.Parse(proof)
+	if err != nil {
+		return "", errs.ErrInvalidDPoPProof
+	}
+
+	if parsed.Method != method || !sameURL(parsed.URL, strings.TrimRight(config.GetInstance().Get("APP_URL"), "/")+path) {
+		return "", errs.ErrInvalidDPoPProof
+	}
+
+	lifetime := proofLifetime()
+	if age := time.Since(parsed.IssuedAt); age > lifetime || age < -lifetime {
+		return "", errs.ErrInvalidDPoPProof
+	}
+
+	if accessToken != "" && parsed.AccessTokenHash != dpop.AccessTokenHash(accessToken) {
+		return "", errs.ErrInvalidDPoPProof
+	}
+
+	// a proof is accepted once, it is remembered as long as its iat may still pass
+	fresh, err := cache.GetInstance().GetClient().SetNX(context.Background(),
+		"dpop-jti-"+parsed.Thumbprint+"-"+parsed.ID, 1, 2*lifetime).Result()
+	if err != nil {
+		return "", errs.SomeThingWentWrong
+	}
+	if !fresh {
+		return "", errs.ErrInvalidDPoPProof
+	}
+
+	return parsed.Thumbprint, nil
+}
+
+// confirmation binds the access token to the DPoP key of the request found in the context, if any.
+func confirmation(ctx context.Context) *Confirmation {
+	if thumbprint, _ := ctx.Value("dpop-jkt").(string); thumbprint != "" {
+		return &Confirmation{JKT: thumbprint}
+	}
+	return nil
+}
+
+// sameURL compares the htu of a proof with the url of the request, ignoring the query, fragment and host case.
+func sameURL(htu, expected string) bool {
+	proofURL, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	expectedURL, err := url.Parse(expected)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(proofURL.Scheme, expectedURL.Scheme) &&
+		strings.EqualFold(proofURL.Host, expectedURL.Host) &&
+		proofURL.Path == expectedURL.Path
+}
+
+// proofLifetime reads DPOP_PROOF_LIFETIME, 60 seconds by default.
+func proofLifetime() time.Duration {
+	seconds, err := strconv.Atoi(config.GetInstance().Get("DPOP_PROOF_LIFETIME"))
+	if err != nil || seconds <= 0 {
+		seconds = 60
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,201 @@
+package authentication
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/cache"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/pkg/dpop"
+)
+
+// redisStandIn answers the few commands the cache is used with here, keeping the keys in memory.
+type redisStandIn struct {
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+func (r *redisStandIn) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go r.handle(conn)
+	}
+}
+
+func (r *redisStandIn) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			_, _ = conn.Write([]byte("+PONG\r\n"))
+		case "HELLO":
+			_, _ = conn.Write([]byte("-ERR unknown command\r\n"))
+		case "SET":
+			_, _ = conn.Write([]byte(r.set(args)))
+		default:
+			_, _ = conn.Write([]byte("+OK\r\n"))
+		}
+	}
+}
+
+// set stores the key, with NX only when it is not set yet.
+func (r *redisStandIn) set(args []string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	nx := false
+	for _, arg := range args[3:] {
+		nx = nx || strings.EqualFold(arg, "NX")
+	}
+	if _, ok := r.keys[args[1]]; ok && nx {
+		return "$-1\r\n"
+	}
+	r.keys[args[1]] = args[2]
+	return "+OK\r\n"
+}
+
+// readCommand reads a command sent as an array of bulk strings.
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected command %q: %v", line, err)
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+
+	args := make([]string, count)
+	for i := range args {
+		if _, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		if args[i], err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(args[i], "\r\n")
+	}
+	return args, nil
+}
+
+var connectCache sync.Once
+
+// useRedisStandIn connects the cache to a redis stand-in once per test binary.
+func useRedisStandIn(t *testing.T) {
+	connectCache.Do(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		go (&redisStandIn{keys: map[string]string{}}).serve(listener)
+
+		host, port, _ := net.SplitHostPort(listener.Addr().String())
+		config.GetInstance().Set("REDIS_HOST", host)
+		config.GetInstance().Set("REDIS_PORT", port)
+		if err = cache.Init(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestDPoPServiceVerify(t *testing.T) {
+	useRedisStandIn(t)
+	config.GetInstance().Set("APP_URL", "https://auth.example.com/")
+	config.GetInstance().Set("DPOP_PROOF_LIFETIME", "60")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk := map[string]interface{}{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+
+	jti := 0
+	proof := func(claims jwt.MapClaims) string {
+		jti++
+		proofClaims := jwt.MapClaims{
+			"jti": "proof-" + strconv.Itoa(jti),
+			"htm": "POST",
+			"htu": "https://auth.example.com/api/v1/authentication/token",
+			"iat": time.Now().Unix(),
+		}
+		for name, value := range claims {
+			proofClaims[name] = value
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, proofClaims)
+		token.Header["typ"] = dpop.ProofType
+		token.Header["jwk"] = jwk
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	service := &DPoPService{}
+	path := "/api/v1/authentication/token"
+
+	tests := []struct {
+		name        string
+		proof       string
+		method      string
+		accessToken string
+		valid       bool
+	}{
+		{"fresh proof", proof(nil), "POST", "", true},
+		{"htu with another path", proof(jwt.MapClaims{"htu": "https://auth.example.com/api/v1/access-tokens/refresh"}), "POST", "", false},
+		{"htu on another host", proof(jwt.MapClaims{"htu": "https://evil.example.com/api/v1/authentication/token"}), "POST", "", false},
+		{"htu with a query and another host case", proof(jwt.MapClaims{"htu": "https://AUTH.example.com/api/v1/authentication/token?a=b"}), "POST", "", true},
+		{"htm of another method", proof(nil), "GET", "", false},
+		{"stale iat", proof(jwt.MapClaims{"iat": time.Now().Add(-2 * time.Minute).Unix()}), "POST", "", false},
+		{"iat in the future", proof(jwt.MapClaims{"iat": time.Now().Add(2 * time.Minute).Unix()}), "POST", "", false},
+		{"ath of the access token", proof(jwt.MapClaims{"ath": dpop.AccessTokenHash("access")}), "POST", "access", true},
+		{"ath of another access token", proof(jwt.MapClaims{"ath": dpop.AccessTokenHash("other")}), "POST", "access", false},
+		{"missing ath", proof(nil), "POST", "access", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			thumbprint, err := service.Verify(test.proof, test.method, path, test.accessToken)
+			if test.valid && (err != nil || thumbprint == "") {
+				t.Fatalf("Verify = %q, %v, want the thumbprint", thumbprint, err)
+			}
+			if !test.valid && !errors.Is(err, errs.ErrInvalidDPoPProof) {
+				t.Fatalf("Verify = %q, %v, want ErrInvalidDPoPProof", thumbprint, err)
+			}
+		})
+	}
+
+	t.Run("replayed jti", func(t *testing.T) {
+		replayed := proof(nil)
+		if _, err := service.Verify(replayed, "POST", path, ""); err != nil {
+			t.Fatalf("first use: %v", err)
+		}
+		if _, err := service.Verify(replayed, "POST", path, ""); !errors.Is(err, errs.ErrInvalidDPoPProof) {
+			t.Fatalf("replay = %v, want ErrInvalidDPoPProof", err)
+		}
+	})
+}
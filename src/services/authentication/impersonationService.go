@@ -0,0 +1,89 @@
+package authentication
+
+import (
+	"context"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+	"strconv"
+	"time"
+)
+
+type IImpersonationService interface {
+	Impersonate(ctx context.Context, adminID uint, user *models.UserModel, reason string) (*JwtDTO, error)
+}
+
+// ImpersonationService lets support staff sign in as a user to see what the user sees.
+// The tokens carry an act claim naming the admin, can not be refreshed and are marked on the session,
+// so the user finds the impersonation among their sessions and audit events.
+type ImpersonationService struct {
+	AdminRepository      repositories.IAdminRepository
+	AccessTokenService   IAccessTokenService
+	JwtService           IJwtService
+	AuthorizationService services.IAuthorizationService
+	AuditService         services.IAuditService
+}
+
+// Impersonate issues short-lived tokens of the user to the admin.
+func (service *ImpersonationService) Impersonate(ctx context.Context, adminID uint, user *models.UserModel, reason string) (*JwtDTO, error) {
+	audit := services.AuditEntry{
+		Event:     services.AuditImpersonationStarted,
+		Outcome:   services.AuditFailure,
+		OwnerID:   user.ID,
+		OwnerType: "user",
+		Metadata:  map[string]interface{}{"reason": reason},
+	}
+
+	if !user.IsActive {
+		service.AuditService.Record(ctx, audit)
+		return nil, errs.ErrUserIsNotActive
+	}
+
+	admin, err := service.AdminRepository.GetByID(adminID)
+	if err != nil {
+		return nil, errs.ErrPermissionDenied
+	}
+	audit.Metadata["admin"] = admin.Uuid
+
+	// get the user permissions to embed in the token
+	permissions, err := service.AuthorizationService.GetOwnerPermissions(user.ID, "user")
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	//generate token
+	jwtDTO, err := service.JwtService.GenerateWithLifetime(CustomClaims{
+		OwnerType:   "user",
+		Permissions: permissions,
+		Act:         &Actor{Subject: admin.Uuid.String()},
+		UserUuid:    user.Uuid.String(),
+	}, ImpersonationLifetime())
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+	jwtDTO.ImpersonatorID = admin.ID
+
+	// Store tokens in database
+	accessToken, err := service.AccessTokenService.Create(ctx, user, jwtDTO)
+	if err != nil {
+		service.AuditService.Record(ctx, audit)
+		return nil, err
+	}
+
+	audit.Outcome = services.AuditSuccess
+	audit.Metadata["session"] = accessToken.Uuid
+	audit.Metadata["expires_at"] = accessToken.AccessTokenExpiresAt
+	service.AuditService.Record(ctx, audit)
+	return jwtDTO, nil
+}
+
+// ImpersonationLifetime reads IMPERSONATION_LIFETIME in minutes, 15 minutes by default.
+func ImpersonationLifetime() time.Duration {
+	minutes, err := strconv.Atoi(config.GetInstance().Get("IMPERSONATION_LIFETIME"))
+	if err != nil || minutes <= 0 {
+		minutes = 15
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,81 @@
+package authentication
+
+import (
+	"context"
+	"go-auth-otp-service/src/services"
+	"time"
+)
+
+type IIntrospectionService interface {
+	Introspect(ctx context.Context, tokenString string) *Introspection
+}
+
+// Introspection describes an access token of a user, inactive tokens only have Active set to false.
+// Tokens bound to a DPoP key have their thumbprint in DPoPJkt, the caller checks the proof.
+// Impersonation tokens name the admin in Actor.
+type Introspection struct {
+	Active      bool       `json:"active"`
+	SessionUuid string     `json:"session_uuid,omitempty"`
+	UserUuid    string     `json:"user_uuid,omitempty"`
+	Permissions []string   `json:"permissions,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	AuthTime    *time.Time `json:"auth_time,omitempty"`
+	Acr         string     `json:"acr,omitempty"`
+	DPoPJkt     string     `json:"dpop_jkt,omitempty"`
+	Actor       string     `json:"actor,omitempty"`
+}
+
+// IntrospectionService lets internal services check the access tokens of users they are called with,
+// the checks are the ones of the AuthenticationMiddleware so revoked sessions and deactivated users are caught.
+type IntrospectionService struct {
+	AccessTokenService   IAccessTokenService
+	UserService          services.IUserService
+	AuthorizationService services.IAuthorizationService
+	AuditService         services.IAuditService
+}
+
+// Introspect validates the access token and describes it.
+func (service *IntrospectionService) Introspect(ctx context.Context, tokenString string) *Introspection {
+	token, claims, err := service.AccessTokenService.ValidateWithClaims(tokenString, AccessToken, "user")
+	if err != nil {
+		return &Introspection{Active: false}
+	}
+
+	user, err := service.UserService.GetByID(token.OwnerID)
+	if err != nil {
+		return &Introspection{Active: false}
+	}
+
+	// the current permissions of the user, the claims keep the revoked roles until the token is refreshed
+	permissions, err := service.AuthorizationService.GetOwnerPermissions(token.OwnerID, token.OwnerType)
+	if err != nil {
+		return &Introspection{Active: false}
+	}
+
+	introspection := &Introspection{
+		Active:      true,
+		SessionUuid: token.Uuid.String(),
+		UserUuid:    user.Uuid.String(),
+		Permissions: permissions,
+		ExpiresAt:   &token.AccessTokenExpiresAt,
+		AuthTime:    token.AuthTime,
+		Acr:         token.Acr,
+		DPoPJkt:     token.DPoPJkt,
+	}
+
+	// the services the impersonation token is used with are audited as well
+	if claims.Act != nil {
+		introspection.Actor = claims.Act.Subject
+		service.AuditService.Record(ctx, services.AuditEntry{
+			Event:     services.AuditImpersonatedRequest,
+			Outcome:   services.AuditSuccess,
+			OwnerID:   token.OwnerID,
+			OwnerType: token.OwnerType,
+			Metadata:  map[string]interface{}{"session": token.Uuid, "method": "introspection"},
+		})
+	}
+
+	// Update last used timestamp
+	_, _ = service.AccessTokenService.UpdateLastUsedAt(token)
+	return introspection
+}
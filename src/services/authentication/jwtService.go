@@ -13,6 +13,9 @@ import (
 // IJwtService defines the interface for JWT operations.
 type IJwtService interface {
 	Generate() (dto *JwtDTO, err error)
+	GenerateWithClaims(customClaims CustomClaims) (dto *JwtDTO, err error)
+	GenerateWithLifetime(customClaims CustomClaims, lifetime time.Duration) (dto *JwtDTO, err error)
+	GenerateAccessToken(customClaims CustomClaims) (dto *JwtDTO, err error)
 	Validate(tokenString string) (*Claims, error)
 }
 
@@ -25,28 +28,92 @@ type JwtDTO struct {
 	RefreshTokenString    string    `json:"refresh_token_string"`
 	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	Scopes                []string  `json:"scopes,omitempty"`
+	TrustedDeviceToken    string    `json:"trusted_device_token,omitempty"`
+	TokenType             string    `json:"token_type,omitempty"`
+	// Acr is how the owner authenticated to get the tokens, sessions opened without a second factor leave it empty.
+	Acr string `json:"-"`
+	// ImpersonatorID is the admin the tokens were issued to while acting as the owner.
+	ImpersonatorID uint `json:"-"`
+}
+
+// CustomClaims defines the application specific claims embedded into the tokens.
+// OwnerType is only set on access tokens, so they are told apart from refresh tokens and the tokens of other owners
+// by the services verifying them offline.
+type CustomClaims struct {
+	OwnerType   string        `json:"owner_type,omitempty"`
+	Permissions []string      `json:"permissions,omitempty"`
+	Cnf         *Confirmation `json:"cnf,omitempty"`
+	Act         *Actor        `json:"act,omitempty"`
+	// UserUuid is set as the subject of the access tokens of users, so they are told who is calling offline.
+	UserUuid string `json:"-"`
+}
+
+// Confirmation holds the thumbprint of the DPoP key an access token is bound to.
+type Confirmation struct {
+	JKT string `json:"jkt"`
+}
+
+// Actor names the admin acting as the owner of an impersonation token.
+type Actor struct {
+	Subject string `json:"sub"`
 }
 
 // Claims defines the structure of the JWT claims.
 type Claims struct {
+	CustomClaims
 	jwt.RegisteredClaims
 }
 
 // Generate generates an access token and a refresh token.
 func (service *JwtService) Generate() (dto *JwtDTO, err error) {
-	tokenUuid, _ := uuid.NewUUID()
-	// Generate access token
+	return service.GenerateWithClaims(CustomClaims{})
+}
+
+// GenerateWithClaims generates an access token and a refresh token carrying the given custom claims.
+func (service *JwtService) GenerateWithClaims(customClaims CustomClaims) (dto *JwtDTO, err error) {
+	accessTokenLifetime, _ := strconv.Atoi(config.GetInstance().Get("JWT_ACCESS_TOKEN_LIFETIME"))
+	refreshTokenLifetime, _ := strconv.Atoi(config.GetInstance().Get("JWT_REFRESH_TOKEN_EXPIRATION"))
+	return generatePair(customClaims, time.Duration(accessTokenLifetime)*time.Second, time.Duration(refreshTokenLifetime)*time.Second)
+}
+
+// GenerateWithLifetime generates tokens which both expire after the lifetime, so the session can not be extended.
+func (service *JwtService) GenerateWithLifetime(customClaims CustomClaims, lifetime time.Duration) (dto *JwtDTO, err error) {
+	return generatePair(customClaims, lifetime, lifetime)
+}
+
+// GenerateAccessToken generates an access token without a refresh token, the session ends when the token expires.
+func (service *JwtService) GenerateAccessToken(customClaims CustomClaims) (dto *JwtDTO, err error) {
 	accessTokenLifetime, _ := strconv.Atoi(config.GetInstance().Get("JWT_ACCESS_TOKEN_LIFETIME"))
+
+	tokenUuid, _ := uuid.NewUUID()
 	accessTokenExpiresAt := time.Now().Add(time.Duration(accessTokenLifetime) * time.Second)
-	accessTokenString, err := generateToken(tokenUuid, accessTokenExpiresAt)
+	accessTokenString, err := generateToken(tokenUuid, accessTokenExpiresAt, customClaims)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return &JwtDTO{
+		Uuid:                  tokenUuid,
+		AccessTokenString:     accessTokenString,
+		AccessTokenExpiresAt:  accessTokenExpiresAt,
+		RefreshTokenExpiresAt: accessTokenExpiresAt,
+	}, nil
+}
+
+// generatePair generates an access token carrying the custom claims and its refresh token.
+func generatePair(customClaims CustomClaims, accessTokenLifetime, refreshTokenLifetime time.Duration) (dto *JwtDTO, err error) {
+	tokenUuid, _ := uuid.NewUUID()
+	// Generate access token
+	accessTokenExpiresAt := time.Now().Add(accessTokenLifetime)
+	accessTokenString, err := generateToken(tokenUuid, accessTokenExpiresAt, customClaims)
 	if err != nil {
 		return nil, errs.SomeThingWentWrong
 	}
 
 	// Generate refresh token
-	refreshTokenLifetime, _ := strconv.Atoi(config.GetInstance().Get("JWT_REFRESH_TOKEN_EXPIRATION"))
-	refreshTokenExpiresAt := time.Now().Add(time.Duration(refreshTokenLifetime) * time.Second)
-	refreshTokenString, err := generateToken(tokenUuid, refreshTokenExpiresAt)
+	refreshTokenExpiresAt := time.Now().Add(refreshTokenLifetime)
+	refreshTokenString, err := generateToken(tokenUuid, refreshTokenExpiresAt, CustomClaims{})
 	if err != nil {
 		return dto, errs.SomeThingWentWrong
 	}
@@ -88,10 +155,12 @@ func (service *JwtService) Validate(tokenString string) (*Claims, error) {
 }
 
 // generateToken creates a token with a specified expiration duration.
-func generateToken(uuid uuid.UUID, expiresAt time.Time) (string, error) {
+func generateToken(uuid uuid.UUID, expiresAt time.Time, customClaims CustomClaims) (string, error) {
 	claims := &Claims{
+		CustomClaims: customClaims,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        uuid.String(),
+			Subject:   customClaims.UserUuid,
 			Issuer:    config.GetInstance().Get("APP_NAME"),
 			Audience:  []string{config.GetInstance().Get("APP_HOST")},
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
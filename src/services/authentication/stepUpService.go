@@ -0,0 +1,122 @@
+package authentication
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+	"time"
+)
+
+// authentication context classes stamped on the sessions
+const (
+	AcrOTP  = "otp"
+	AcrTOTP = "totp"
+)
+
+type IStepUpService interface {
+	Challenge(ctx context.Context, ownerID uint, ownerType string) (method string, err error)
+	Verify(ctx context.Context, accessTokenUuid *uuid.UUID, ownerID uint, ownerType, code string) (*models.AccessTokenModel, error)
+}
+
+// StepUpService lets the owner of a session authenticate again, so routes guarded by
+// RequireRecentAuth accept the session for a while.
+// Admins with an enabled totp verify it, every other owner verifies an otp sent to their mobile.
+type StepUpService struct {
+	UserService           services.IUserService
+	AdminRepository       repositories.IAdminRepository
+	OTPService            services.IOTPService
+	AccessTokenRepository repositories.IAccessTokenRepository
+	AuditService          services.IAuditService
+}
+
+// Challenge sends an otp when the owner has to verify one and returns the method to verify.
+func (service *StepUpService) Challenge(ctx context.Context, ownerID uint, ownerType string) (string, error) {
+	mobile, totpAdmin, err := service.secondFactor(ownerID, ownerType)
+	if err != nil {
+		return "", err
+	}
+	if totpAdmin != nil {
+		return AcrTOTP, nil
+	}
+
+	if err = service.OTPService.RequestOTP(mobile); err != nil {
+		return "", err
+	}
+	return AcrOTP, nil
+}
+
+// Verify checks the code and stamps the session with the current time as its auth time.
+func (service *StepUpService) Verify(ctx context.Context, accessTokenUuid *uuid.UUID, ownerID uint, ownerType, code string) (*models.AccessTokenModel, error) {
+	accessToken, err := service.AccessTokenRepository.GetByUuid(accessTokenUuid)
+	if err != nil || accessToken.OwnerID != ownerID || accessToken.OwnerType != ownerType {
+		return nil, errs.ErrAuthenticationFailed
+	}
+
+	mobile, totpAdmin, err := service.secondFactor(ownerID, ownerType)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := services.AuditEntry{
+		Event:     services.AuditStepUp,
+		Outcome:   services.AuditFailure,
+		OwnerID:   ownerID,
+		OwnerType: ownerType,
+		Metadata:  map[string]interface{}{"session": accessToken.Uuid},
+	}
+
+	acr := AcrOTP
+	if totpAdmin != nil {
+		acr = AcrTOTP
+		// the time step is consumed like at login, so a code seen once can not be replayed
+		if !useTotpCode(service.AdminRepository, totpAdmin, code) {
+			service.AuditService.Record(ctx, audit)
+			return nil, errs.ErrInvalid2FACode
+		}
+	} else {
+		otpIsValid, err := service.OTPService.VerifyOTP(mobile, code)
+		if err != nil {
+			return nil, errs.SomeThingWentWrong
+		}
+		if !otpIsValid {
+			service.AuditService.Record(ctx, audit)
+			return nil, errs.ErrOTPInvalid
+		}
+	}
+
+	accessToken, err = service.AccessTokenRepository.UpdateAuthTime(accessToken, time.Now(), acr)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	audit.Outcome = services.AuditSuccess
+	audit.Metadata["acr"] = acr
+	service.AuditService.Record(ctx, audit)
+	return accessToken, nil
+}
+
+// secondFactor returns the mobile of the owner and the admin itself when it enabled totp.
+func (service *StepUpService) secondFactor(ownerID uint, ownerType string) (string, *models.AdminModel, error) {
+	switch ownerType {
+	case "user":
+		user, err := service.UserService.GetByID(ownerID)
+		if err != nil {
+			return "", nil, errs.RecordNotFound
+		}
+		return user.Mobile, nil, nil
+	case "admin":
+		admin, err := service.AdminRepository.GetByID(ownerID)
+		if err != nil {
+			return "", nil, errs.RecordNotFound
+		}
+		if admin.TotpEnabled {
+			return admin.Mobile, admin, nil
+		}
+		return admin.Mobile, nil, nil
+	default:
+		return "", nil, errs.ErrPermissionDenied
+	}
+}
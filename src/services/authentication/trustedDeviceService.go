@@ -0,0 +1,222 @@
+package authentication
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/api/http/requests/authentication"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/hash"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/useragent"
+	"go-auth-otp-service/src/pkg/utils"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/services"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrustedDeviceTokenPrefix makes trusted device tokens recognisable.
+const TrustedDeviceTokenPrefix = "tdt_"
+
+type ITrustedDeviceService interface {
+	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	Trust(ctx context.Context, user *models.UserModel, deviceID string, accessToken *models.AccessTokenModel) (string, error)
+	Login(ctx context.Context, req *authentication.TrustedDeviceLoginRequest) (*JwtDTO, error)
+	Revoke(trustedDeviceUuid *uuid.UUID, userID uint) error
+	RevokeAll(userID uint) error
+}
+
+// TrustedDeviceService lets a device the user chose to remember get new tokens without an otp.
+// The device proves itself with its device id and a token which is rotated on every use.
+type TrustedDeviceService struct {
+	TrustedDeviceRepository repositories.ITrustedDeviceRepository
+	UserService             services.IUserService
+	AccessTokenService      IAccessTokenService
+	JwtService              IJwtService
+	AuthorizationService    services.IAuthorizationService
+	AuditService            services.IAuditService
+}
+
+func (service *TrustedDeviceService) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	res, err := service.TrustedDeviceRepository.GetList(builder)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return res, nil
+}
+
+// Trust remembers the device for TRUSTED_DEVICE_LIFETIME_DAYS and returns its plain token, which is never stored.
+// The device is tied to the session which trusted it, so revoking that session revokes the device as well.
+func (service *TrustedDeviceService) Trust(ctx context.Context, user *models.UserModel, deviceID string, accessToken *models.AccessTokenModel) (string, error) {
+	tokenID, secret, err := generateTrustedDeviceToken()
+	if err != nil {
+		return "", errs.SomeThingWentWrong
+	}
+
+	ip, _ := ctx.Value("request-ip").(string)
+	userAgent, _ := ctx.Value("request-user-agent").(string)
+	name := headerValue(ctx, "request-device-name", 100)
+	if name == "" {
+		name = useragent.Parse(userAgent).Name()
+	}
+
+	_, err = service.TrustedDeviceRepository.Create(&models.TrustedDeviceModel{
+		Uuid:          uuid.New(),
+		UserID:        user.ID,
+		AccessTokenID: &accessToken.ID,
+		DeviceID:      deviceID,
+		Name:          name,
+		TokenID:       tokenID,
+		Token:         []byte(secret),
+		IP:            ip,
+		UserAgent:     userAgent,
+		ExpiresAt:     time.Now().Add(TrustedDeviceLifetime()),
+	})
+	if err != nil {
+		return "", errs.SomeThingWentWrong
+	}
+
+	return formatTrustedDeviceToken(tokenID, secret), nil
+}
+
+// Login issues new tokens to a trusted device of the user owning the mobile, the device token is rotated.
+func (service *TrustedDeviceService) Login(ctx context.Context, req *authentication.TrustedDeviceLoginRequest) (*JwtDTO, error) {
+	audit := services.AuditEntry{
+		Event:     services.AuditLogin,
+		Outcome:   services.AuditFailure,
+		OwnerType: "user",
+		Metadata:  services.WithMobileHash(map[string]interface{}{"method": "trusted-device"}, req.Mobile),
+	}
+
+	trustedDevice, user, err := service.verify(req)
+	if user != nil {
+		audit.OwnerID = user.ID
+	}
+	if err != nil {
+		service.AuditService.Record(ctx, audit)
+		return nil, err
+	}
+
+	// rotate the token so a stolen copy stops working once the device uses it
+	tokenID, secret, err := generateTrustedDeviceToken()
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+	if _, err = service.TrustedDeviceRepository.RotateToken(trustedDevice, tokenID, []byte(secret), time.Now()); err != nil {
+		if errors.Is(err, repositories.ErrTrustedDeviceTokenRotated) {
+			service.AuditService.Record(ctx, audit)
+			return nil, errs.ErrInvalidToken
+		}
+		return nil, errs.SomeThingWentWrong
+	}
+
+	// get the user permissions to embed in the token
+	permissions, err := service.AuthorizationService.GetOwnerPermissions(user.ID, "user")
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	//generate token
+	jwtDTO, err := service.JwtService.GenerateWithClaims(CustomClaims{OwnerType: "user", Permissions: permissions, Cnf: confirmation(ctx), UserUuid: user.Uuid.String()})
+	if err != nil {
+		return nil, errs.ErrAuthenticationFailed
+	}
+
+	// Store tokens in database
+	if _, err = service.AccessTokenService.Create(ctx, user, jwtDTO); err != nil {
+		return nil, err
+	}
+	jwtDTO.TrustedDeviceToken = formatTrustedDeviceToken(tokenID, secret)
+
+	audit.Outcome = services.AuditSuccess
+	audit.Metadata["trusted_device"] = trustedDevice.Uuid
+	service.AuditService.Record(ctx, audit)
+	return jwtDTO, nil
+}
+
+func (service *TrustedDeviceService) Revoke(trustedDeviceUuid *uuid.UUID, userID uint) error {
+	trustedDevice, err := service.TrustedDeviceRepository.GetByUuid(trustedDeviceUuid)
+	if err != nil || trustedDevice.UserID != userID {
+		return errs.RecordNotFound
+	}
+
+	if err = service.TrustedDeviceRepository.Delete(trustedDevice); err != nil {
+		return errs.SomeThingWentWrong
+	}
+	return nil
+}
+
+func (service *TrustedDeviceService) RevokeAll(userID uint) error {
+	if err := service.TrustedDeviceRepository.DeleteAll(userID); err != nil {
+		return errs.SomeThingWentWrong
+	}
+	return nil
+}
+
+// verify checks the token, the device id, the expiry and the owner of the trusted device.
+func (service *TrustedDeviceService) verify(req *authentication.TrustedDeviceLoginRequest) (*models.TrustedDeviceModel, *models.UserModel, error) {
+	parts := strings.SplitN(strings.TrimPrefix(req.Token, TrustedDeviceTokenPrefix), "_", 2)
+	if !strings.HasPrefix(req.Token, TrustedDeviceTokenPrefix) || len(parts) != 2 {
+		return nil, nil, errs.ErrInvalidToken
+	}
+
+	trustedDevice, err := service.TrustedDeviceRepository.GetByTokenID(parts[0])
+	if err != nil {
+		return nil, nil, errs.ErrInvalidToken
+	}
+
+	user, err := service.UserService.GetByID(trustedDevice.UserID)
+	if err != nil {
+		return nil, nil, errs.ErrInvalidToken
+	}
+
+	hashCheck, err := hash.VerifyStoredHash(trustedDevice.Token, parts[1])
+	if err != nil || !hashCheck || trustedDevice.DeviceID != req.DeviceID || user.Mobile != req.Mobile {
+		return nil, user, errs.ErrAuthenticationFailed
+	}
+
+	if trustedDevice.ExpiresAt.Before(time.Now()) {
+		return nil, user, errs.ErrTokenExpired
+	}
+
+	if !user.IsActive {
+		return nil, user, errs.ErrUserIsNotActive
+	}
+	return trustedDevice, user, nil
+}
+
+// generateTrustedDeviceToken returns a new token id and secret.
+func generateTrustedDeviceToken() (string, string, error) {
+	tokenID, err := utils.GenerateSalt(8)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret, err := utils.GenerateSalt(32)
+	if err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(tokenID), base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// formatTrustedDeviceToken builds the plain token of the form tdt_<token-id>_<secret>.
+func formatTrustedDeviceToken(tokenID, secret string) string {
+	return fmt.Sprintf("%s%s_%s", TrustedDeviceTokenPrefix, tokenID, secret)
+}
+
+// TrustedDeviceLifetime reads TRUSTED_DEVICE_LIFETIME_DAYS, 30 days by default.
+func TrustedDeviceLifetime() time.Duration {
+	days, err := strconv.Atoi(config.GetInstance().Get("TRUSTED_DEVICE_LIFETIME_DAYS"))
+	if err != nil || days <= 0 {
+		days = 30
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/database/scopes"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/permissions"
+	"go-auth-otp-service/src/repositories"
+)
+
+type IAuthorizationService interface {
+	GetRoles(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	GetPermissions(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
+	GetOwnerRoles(ownerID uint, ownerType string) ([]*models.RoleModel, error)
+	GetOwnerPermissions(ownerID uint, ownerType string) ([]string, error)
+	AssignRole(ownerID uint, ownerType string, roleName string) error
+	RevokeRole(ownerID uint, ownerType string, roleName string) error
+}
+
+type AuthorizationService struct {
+	RoleRepository       repositories.IRoleRepository
+	PermissionRepository repositories.IPermissionRepository
+}
+
+func (service *AuthorizationService) GetRoles(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	res, err := service.RoleRepository.GetList(builder)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return res, nil
+}
+
+func (service *AuthorizationService) GetPermissions(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
+	res, err := service.PermissionRepository.GetList(builder)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return res, nil
+}
+
+func (service *AuthorizationService) GetOwnerRoles(ownerID uint, ownerType string) ([]*models.RoleModel, error) {
+	res, err := service.RoleRepository.GetOwnerRoles(ownerID, ownerType)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return res, nil
+}
+
+func (service *AuthorizationService) GetOwnerPermissions(ownerID uint, ownerType string) ([]string, error) {
+	res, err := service.PermissionRepository.GetOwnerPermissions(ownerID, ownerType)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	return res, nil
+}
+
+// AssignRole assigns a role to an owner. The super-admin role can only be granted by the seeders.
+func (service *AuthorizationService) AssignRole(ownerID uint, ownerType string, roleName string) error {
+	if roleName == permissions.SuperAdminRole {
+		return errs.CantChangeSuperAdmin
+	}
+
+	role, err := service.RoleRepository.GetByName(roleName)
+	if err != nil {
+		return errs.RecordNotFound
+	}
+
+	err = service.RoleRepository.Assign(ownerID, ownerType, role)
+	if err != nil {
+		return errs.SomeThingWentWrong
+	}
+	return nil
+}
+
+// RevokeRole removes a role from an owner. The super-admin role can only be changed by the seeders.
+// The permissions are read from the database on every request, so the role stops working at once for the sessions
+// of the owner, only the claims of their access tokens keep it until they are refreshed.
+func (service *AuthorizationService) RevokeRole(ownerID uint, ownerType string, roleName string) error {
+	if roleName == permissions.SuperAdminRole {
+		return errs.CantChangeSuperAdmin
+	}
+
+	role, err := service.RoleRepository.GetByName(roleName)
+	if err != nil {
+		return errs.RecordNotFound
+	}
+
+	err = service.RoleRepository.Revoke(ownerID, ownerType, role)
+	if err != nil {
+		return errs.SomeThingWentWrong
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-auth-otp-service/src/cache"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/database"
+	"go-auth-otp-service/src/database/migrations"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// health check statuses
+const (
+	HealthUp   = "up"
+	HealthDown = "down"
+)
+
+// readinessTimeout bounds every dependency check, so a hanging dependency fails the probe instead of timing it out.
+const readinessTimeout = 2 * time.Second
+
+// shuttingDown is set once the application received a termination signal.
+var shuttingDown atomic.Bool
+
+// MarkShuttingDown makes the readiness fail, so the instance is taken out of the load balancer before it stops.
+func MarkShuttingDown() {
+	shuttingDown.Store(true)
+}
+
+// Readiness is the status of the dependencies the api needs to serve requests.
+type Readiness struct {
+	Ready        bool                    `json:"ready"`
+	ShuttingDown bool                    `json:"shutting_down"`
+	Checks       map[string]*HealthCheck `json:"checks"`
+}
+
+// HealthCheck is the status of a dependency and how long checking it took.
+type HealthCheck struct {
+	Status    string         `json:"status"`
+	LatencyMs float64        `json:"latency_ms"`
+	Error     string         `json:"error,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+type IHealthService interface {
+	Ready(ctx context.Context) *Readiness
+}
+
+type HealthService struct {
+	Database *database.Database
+	Cache    *cache.Cache
+}
+
+// Ready checks the database, the cache, the migrations and the signing keys concurrently.
+func (service *HealthService) Ready(ctx context.Context) *Readiness {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	checks := map[string]func(context.Context) (map[string]any, error){
+		"database":     service.checkDatabase,
+		"cache":        service.checkCache,
+		"migrations":   service.checkMigrations,
+		"signing_keys": service.checkSigningKeys,
+	}
+
+	readiness := &Readiness{
+		Ready:        true,
+		ShuttingDown: shuttingDown.Load(),
+		Checks:       make(map[string]*HealthCheck, len(checks)),
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			details, err := check(ctx)
+			result := &HealthCheck{
+				Status:    HealthUp,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+				Details:   details,
+			}
+			if err != nil {
+				result.Status = HealthDown
+				result.Error = err.Error()
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			readiness.Checks[name] = result
+			if err != nil {
+				readiness.Ready = false
+			}
+		}()
+	}
+	wg.Wait()
+
+	if readiness.ShuttingDown {
+		readiness.Ready = false
+	}
+	return readiness
+}
+
+func (service *HealthService) checkDatabase(ctx context.Context) (map[string]any, error) {
+	return nil, service.Database.GetDB().PingContext(ctx)
+}
+
+func (service *HealthService) checkCache(ctx context.Context) (map[string]any, error) {
+	return nil, service.Cache.GetClient().Ping(ctx).Err()
+}
+
+// checkMigrations compares the version of the database with the last embedded migration.
+func (service *HealthService) checkMigrations(ctx context.Context) (map[string]any, error) {
+	latest, err := migrations.Latest()
+	if err != nil {
+		return nil, err
+	}
+
+	var version uint
+	var dirty bool
+	err = service.Database.GetClient().WithContext(ctx).
+		Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").
+		Row().Scan(&version, &dirty)
+	if err != nil {
+		return map[string]any{"latest": latest}, fmt.Errorf("reading the migration version: %w", err)
+	}
+
+	details := map[string]any{"version": version, "latest": latest, "dirty": dirty}
+	switch {
+	case dirty:
+		return details, errors.New("the last migration failed and the database is dirty")
+	case version < latest:
+		return details, errors.New("the database is not migrated to the latest version")
+	}
+	return details, nil
+}
+
+// checkSigningKeys reports the keys the tokens and signed links are signed with which are not configured.
+func (service *HealthService) checkSigningKeys(context.Context) (map[string]any, error) {
+	keys := []string{"JWT_SECRET", "DEVICE_ALERT_SIGNING_KEY", "AUDIT_HASH_KEY"}
+	if driver := config.GetInstance().Get("STORAGE_DRIVER"); driver == "" || driver == "local" {
+		keys = append(keys, "STORAGE_SIGNING_KEY")
+	}
+
+	var missing []string
+	for _, key := range keys {
+		if config.GetInstance().Get(key) == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return map[string]any{"missing": missing}, errors.New("signing keys are not configured")
+	}
+	return nil, nil
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go-auth-otp-service/src/api/errs"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/models"
+	"go-auth-otp-service/src/pkg/imaging"
+	"go-auth-otp-service/src/pkg/utils"
+	"go-auth-otp-service/src/repositories"
+	"go-auth-otp-service/src/storage"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	profileImageOriginalSize = 1024
+	profileImageQuality      = 85
+)
+
+// ProfileImageThumbnails maps the name of every generated thumbnail to its maximum side.
+var ProfileImageThumbnails = map[string]int{
+	"small":  64,
+	"medium": 256,
+}
+
+type IProfileImageService interface {
+	Upload(user *models.UserModel, file *multipart.FileHeader) (*models.UserModel, error)
+	Delete(user *models.UserModel) (*models.UserModel, error)
+	URLs(user *models.UserModel) (map[string]string, error)
+}
+
+type ProfileImageService struct {
+	UserRepository repositories.IUserRepository
+}
+
+// Upload validates the image, strips its metadata by re-encoding it, stores it alongside its thumbnails
+// and replaces the previous profile image of the user.
+func (service *ProfileImageService) Upload(user *models.UserModel, file *multipart.FileHeader) (*models.UserModel, error) {
+	maxSize := ProfileImageMaxSize()
+	if file.Size > maxSize {
+		return nil, errs.ErrImageTooLarge
+	}
+
+	// read the file, never trusting the declared size
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errs.ErrInvalidImage
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, errs.ErrInvalidImage
+	}
+	if int64(len(data)) > maxSize {
+		return nil, errs.ErrImageTooLarge
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		if err == imaging.ErrUnsupportedType {
+			return nil, errs.ErrUnsupportedImageType
+		}
+		return nil, errs.ErrInvalidImage
+	}
+
+	// store the original and its thumbnails
+	ctx := context.Background()
+	base := fmt.Sprintf("profile-images/%s/%s", user.Uuid, strings.ToLower(utils.GenerateRandomString(16)))
+	sizes := map[string]int{"": profileImageOriginalSize}
+	for name, size := range ProfileImageThumbnails {
+		sizes[name] = size
+	}
+
+	stored := make([]string, 0, len(sizes))
+	for name, size := range sizes {
+		body, err := imaging.EncodeJPEG(imaging.Fit(img, size), profileImageQuality)
+		if err != nil {
+			deleteFiles(ctx, stored)
+			return nil, errs.SomeThingWentWrong
+		}
+
+		path := profileImagePath(base+".jpg", name)
+		if err = storage.GetInstance().Put(ctx, path, body, "image/jpeg"); err != nil {
+			deleteFiles(ctx, stored)
+			return nil, errs.SomeThingWentWrong
+		}
+		stored = append(stored, path)
+	}
+
+	previous := user.ProfileImage
+	user.ProfileImage = base + ".jpg"
+	user, err = service.UserRepository.Update(user)
+	if err != nil {
+		deleteFiles(ctx, stored)
+		return nil, errs.SomeThingWentWrong
+	}
+
+	deleteFiles(ctx, profileImagePaths(previous))
+	return user, nil
+}
+
+// Delete removes the profile image of the user and its thumbnails.
+func (service *ProfileImageService) Delete(user *models.UserModel) (*models.UserModel, error) {
+	if user.ProfileImage == "" {
+		return nil, errs.RecordNotFound
+	}
+
+	previous := user.ProfileImage
+	user.ProfileImage = ""
+	user, err := service.UserRepository.Update(user)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	deleteFiles(context.Background(), profileImagePaths(previous))
+	return user, nil
+}
+
+// URLs returns expiring urls of the profile image and its thumbnails keyed by thumbnail name,
+// the original is keyed as "original".
+func (service *ProfileImageService) URLs(user *models.UserModel) (map[string]string, error) {
+	if user.ProfileImage == "" {
+		return nil, errs.RecordNotFound
+	}
+
+	lifetime, err := strconv.Atoi(config.GetInstance().Get("STORAGE_URL_LIFETIME"))
+	if err != nil {
+		lifetime = 900
+	}
+
+	urls := make(map[string]string, len(ProfileImageThumbnails)+1)
+	original, err := storage.GetInstance().URL(user.ProfileImage, time.Duration(lifetime)*time.Second)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+	urls["original"] = original
+
+	for name := range ProfileImageThumbnails {
+		url, err := storage.GetInstance().URL(profileImagePath(user.ProfileImage, name), time.Duration(lifetime)*time.Second)
+		if err != nil {
+			return nil, errs.SomeThingWentWrong
+		}
+		urls[name] = url
+	}
+
+	return urls, nil
+}
+
+// deleteFiles removes stored files on a best-effort basis.
+func deleteFiles(ctx context.Context, paths []string) {
+	for _, path := range paths {
+		_ = storage.GetInstance().Delete(ctx, path)
+	}
+}
+
+// profileImagePath returns the path of a thumbnail, an empty name is the original image.
+func profileImagePath(original, name string) string {
+	if name == "" {
+		return original
+	}
+	return strings.TrimSuffix(original, ".jpg") + "_" + name + ".jpg"
+}
+
+// profileImagePaths returns the paths of the image and all of its thumbnails.
+func profileImagePaths(original string) []string {
+	if original == "" {
+		return nil
+	}
+
+	paths := []string{original}
+	for name := range ProfileImageThumbnails {
+		paths = append(paths, profileImagePath(original, name))
+	}
+	return paths
+}
+
+// ProfileImageMaxSize returns the largest accepted profile image in bytes, 5 MB unless configured.
+func ProfileImageMaxSize() int64 {
+	maxSize, err := strconv.ParseInt(config.GetInstance().Get("PROFILE_IMAGE_MAX_SIZE"), 10, 64)
+	if err != nil {
+		return 5 << 20
+	}
+	return maxSize
+}
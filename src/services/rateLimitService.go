@@ -74,7 +74,7 @@ func (s *RateLimitService) GetOnExceedHandler(c *gin.Context, resetIn string) bo
 
 func (s *RateLimitService) PostOnExceedHandler(c *gin.Context, resetIn string) bool {
 	response.Api(c).SetStatusCode(http.StatusTooManyRequests).
-		SetMessage(fmt.Sprintf(errs.TooManyRequest.Error())).
+		SetMessage(errs.TooManyRequest.Error()).
 		SetLog().Send()
 	return false
 }
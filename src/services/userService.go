@@ -1,25 +1,43 @@
 package services
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"go-auth-otp-service/src/api/errs"
 	"go-auth-otp-service/src/api/http/requests/userRequests"
 	"go-auth-otp-service/src/database/scopes"
 	"go-auth-otp-service/src/models"
 	"go-auth-otp-service/src/repositories"
+	"reflect"
+	"strings"
+	"time"
 )
 
 type IUserService interface {
 	GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error)
 	Update(user *models.UserModel) (*models.UserModel, error)
+	GetByID(id uint) (*models.UserModel, error)
 	GetByUuid(uuid *uuid.UUID) (*models.UserModel, error)
+	GetByUuidWithTrashed(uuid *uuid.UUID) (*models.UserModel, error)
 	Create(request *userRequests.CreateRequest) (*models.UserModel, error)
+	CreateByAdmin(ctx context.Context, request *userRequests.AdminCreateRequest) (*models.UserModel, error)
+	UpdateAttributes(ctx context.Context, user *models.UserModel, request *userRequests.UpdateRequest) (*models.UserModel, error)
+	UpdateProfile(ctx context.Context, user *models.UserModel, request *userRequests.UpdateProfileRequest) (*models.UserModel, error)
+	Deactivate(ctx context.Context, user *models.UserModel) (*models.UserModel, error)
+	Reactivate(ctx context.Context, user *models.UserModel) (*models.UserModel, error)
+	Delete(ctx context.Context, user *models.UserModel) error
+	Restore(ctx context.Context, user *models.UserModel) (*models.UserModel, error)
+	ForceDelete(ctx context.Context, user *models.UserModel) error
+	RequestDeletion(ctx context.Context, user *models.UserModel) error
+	AnonymizeDeletedUsers(gracePeriod time.Duration) (int, error)
 	GetByNationalIdentityCode(nationalIdentityCode string) (*models.UserModel, error)
 	GetByMobile(mobile string) (*models.UserModel, error)
+	GetByMobileWithTrashed(mobile string) (*models.UserModel, error)
 }
 
 type UserService struct {
 	UserRepository repositories.IUserRepository
+	AuditService   IAuditService
 }
 
 func (service *UserService) GetList(builder *scopes.BuilderModel) (*scopes.PaginateModel, error) {
@@ -31,6 +49,15 @@ func (service *UserService) GetList(builder *scopes.BuilderModel) (*scopes.Pagin
 	return res, nil
 }
 
+func (service *UserService) GetByID(id uint) (*models.UserModel, error) {
+	res, err := service.UserRepository.GetByID(id)
+	if err != nil {
+		return nil, errs.RecordNotFound
+	}
+
+	return res, nil
+}
+
 func (service *UserService) GetByUuid(uuid *uuid.UUID) (*models.UserModel, error) {
 	res, err := service.UserRepository.GetByUuid(uuid)
 	if err != nil {
@@ -40,6 +67,15 @@ func (service *UserService) GetByUuid(uuid *uuid.UUID) (*models.UserModel, error
 	return res, nil
 }
 
+func (service *UserService) GetByUuidWithTrashed(uuid *uuid.UUID) (*models.UserModel, error) {
+	res, err := service.UserRepository.GetByUuidWithTrashed(uuid)
+	if err != nil {
+		return nil, errs.RecordNotFound
+	}
+
+	return res, nil
+}
+
 func (service *UserService) GetByNationalIdentityCode(nationalIdentityCode string) (*models.UserModel, error) {
 	res, err := service.UserRepository.GetByNationalIdentityCode(nationalIdentityCode)
 	if err != nil {
@@ -56,6 +92,14 @@ func (service *UserService) GetByMobile(mobile string) (*models.UserModel, error
 	return res, nil
 }
 
+func (service *UserService) GetByMobileWithTrashed(mobile string) (*models.UserModel, error) {
+	res, err := service.UserRepository.GetByMobileWithTrashed(mobile)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (service *UserService) Update(user *models.UserModel) (*models.UserModel, error) {
 	res, err := service.UserRepository.Update(user)
 	if err != nil {
@@ -81,3 +125,226 @@ func (service *UserService) Create(request *userRequests.CreateRequest) (*models
 
 	return userOrm, nil
 }
+
+func (service *UserService) CreateByAdmin(ctx context.Context, request *userRequests.AdminCreateRequest) (*models.UserModel, error) {
+	if err := service.checkUniqueness(0, request.Mobile, request.NationalIdentityCode, request.Email); err != nil {
+		return nil, err
+	}
+
+	user := &models.UserModel{
+		Uuid:                 uuid.New(),
+		IsActive:             true,
+		FirstName:            request.FirstName,
+		LastName:             request.LastName,
+		FatherName:           request.FatherName,
+		NationalIdentityCode: request.NationalIdentityCode,
+		Mobile:               request.Mobile,
+		Email:                request.Email,
+	}
+	userOrm, err := service.UserRepository.Create(user)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	service.record(ctx, AuditUserCreated, userOrm, nil)
+	return userOrm, nil
+}
+
+// UpdateAttributes applies every non-nil field of the request to the user.
+func (service *UserService) UpdateAttributes(ctx context.Context, user *models.UserModel, request *userRequests.UpdateRequest) (*models.UserModel, error) {
+	user, err := service.updateAttributes(user, request)
+	if err != nil {
+		return nil, err
+	}
+
+	service.record(ctx, AuditProfileUpdated, user, updatedFields(request))
+	return user, nil
+}
+
+// updateAttributes applies every non-nil field of the request to the user without recording it.
+func (service *UserService) updateAttributes(user *models.UserModel, request *userRequests.UpdateRequest) (*models.UserModel, error) {
+	var mobile, nationalIdentityCode, email string
+	if request.Mobile != nil && *request.Mobile != user.Mobile {
+		mobile = *request.Mobile
+	}
+	if request.NationalIdentityCode != nil && *request.NationalIdentityCode != user.NationalIdentityCode {
+		nationalIdentityCode = *request.NationalIdentityCode
+	}
+	if request.Email != nil && *request.Email != user.Email {
+		email = *request.Email
+	}
+	if err := service.checkUniqueness(user.ID, mobile, nationalIdentityCode, email); err != nil {
+		return nil, err
+	}
+
+	if request.FirstName != nil {
+		user.FirstName = *request.FirstName
+	}
+	if request.LastName != nil {
+		user.LastName = *request.LastName
+	}
+	if request.FatherName != nil {
+		user.FatherName = *request.FatherName
+	}
+	if request.NationalIdentityCode != nil {
+		user.NationalIdentityCode = *request.NationalIdentityCode
+	}
+	if request.Mobile != nil {
+		user.Mobile = *request.Mobile
+	}
+	if request.Email != nil {
+		user.Email = *request.Email
+	}
+
+	return service.Update(user)
+}
+
+// UpdateProfile applies the fields a user may change on their own profile.
+func (service *UserService) UpdateProfile(ctx context.Context, user *models.UserModel, request *userRequests.UpdateProfileRequest) (*models.UserModel, error) {
+	return service.UpdateAttributes(ctx, user, &userRequests.UpdateRequest{
+		FirstName:            request.FirstName,
+		LastName:             request.LastName,
+		FatherName:           request.FatherName,
+		NationalIdentityCode: request.NationalIdentityCode,
+		Email:                request.Email,
+	})
+}
+
+func (service *UserService) Deactivate(ctx context.Context, user *models.UserModel) (*models.UserModel, error) {
+	user.IsActive = false
+	user, err := service.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	service.record(ctx, AuditUserDeactivated, user, nil)
+	return user, nil
+}
+
+func (service *UserService) Reactivate(ctx context.Context, user *models.UserModel) (*models.UserModel, error) {
+	user.IsActive = true
+	user, err := service.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	service.record(ctx, AuditUserReactivated, user, nil)
+	return user, nil
+}
+
+func (service *UserService) Delete(ctx context.Context, user *models.UserModel) error {
+	err := service.UserRepository.Delete(user)
+	if err != nil {
+		return errs.SomeThingWentWrong
+	}
+
+	service.record(ctx, AuditUserDeleted, user, nil)
+	return nil
+}
+
+func (service *UserService) Restore(ctx context.Context, user *models.UserModel) (*models.UserModel, error) {
+	res, err := service.UserRepository.Restore(user)
+	if err != nil {
+		return nil, errs.SomeThingWentWrong
+	}
+
+	service.record(ctx, AuditUserRestored, res, nil)
+	return res, nil
+}
+
+func (service *UserService) ForceDelete(ctx context.Context, user *models.UserModel) error {
+	err := service.UserRepository.ForceDelete(user)
+	if err != nil {
+		return errs.SomeThingWentWrong
+	}
+
+	// the stored profile image is not removed with the row
+	deleteFiles(ctx, profileImagePaths(user.ProfileImage))
+
+	service.record(ctx, AuditUserForceDeleted, user, nil)
+	return nil
+}
+
+// RequestDeletion soft-deletes the user on their own request, the personal data is anonymised once the grace period is over.
+func (service *UserService) RequestDeletion(ctx context.Context, user *models.UserModel) error {
+	err := service.UserRepository.RequestDeletion(user)
+	if err != nil {
+		return errs.SomeThingWentWrong
+	}
+
+	service.record(ctx, AuditUserDeletionRequested, user, nil)
+	return nil
+}
+
+// AnonymizeDeletedUsers erases the personal data of users whose deletion was requested longer than the grace period ago.
+// It returns the number of anonymised users.
+func (service *UserService) AnonymizeDeletedUsers(gracePeriod time.Duration) (int, error) {
+	const batchSize = 100
+	anonymized := 0
+
+	for {
+		users, err := service.UserRepository.GetDeletionRequestedBefore(time.Now().Add(-gracePeriod), batchSize)
+		if err != nil {
+			return anonymized, err
+		}
+
+		for _, user := range users {
+			deleteFiles(context.Background(), profileImagePaths(user.ProfileImage))
+
+			if err = service.UserRepository.Anonymize(user); err != nil {
+				return anonymized, err
+			}
+			service.record(context.Background(), AuditUserAnonymized, user, nil)
+			anonymized++
+		}
+
+		if len(users) < batchSize {
+			return anonymized, nil
+		}
+	}
+}
+
+// checkUniqueness makes sure no other user already owns the given credentials, empty values are skipped.
+// Soft-deleted users are included since the unique indexes still cover their rows.
+func (service *UserService) checkUniqueness(userID uint, mobile, nationalIdentityCode, email string) error {
+	if mobile != "" {
+		if user, err := service.UserRepository.GetByMobileWithTrashed(mobile); err == nil && user.ID != userID {
+			return errs.ErrUserAlreadyExists
+		}
+	}
+	if nationalIdentityCode != "" {
+		if user, err := service.UserRepository.GetByNationalIdentityCodeWithTrashed(nationalIdentityCode); err == nil && user.ID != userID {
+			return errs.ErrUserAlreadyExists
+		}
+	}
+	if email != "" {
+		if user, err := service.UserRepository.GetByEmailWithTrashed(email); err == nil && user.ID != userID {
+			return errs.ErrUserAlreadyExists
+		}
+	}
+
+	return nil
+}
+
+// record appends a successful event about the user to the audit log.
+func (service *UserService) record(ctx context.Context, event string, user *models.UserModel, metadata map[string]interface{}) {
+	service.AuditService.Record(ctx, AuditEntry{
+		Event:     event,
+		Outcome:   AuditSuccess,
+		OwnerID:   user.ID,
+		OwnerType: "user",
+		Metadata:  metadata,
+	})
+}
+
+// updatedFields lists the json names of the fields changed by an update request, never their values.
+func updatedFields(request *userRequests.UpdateRequest) map[string]interface{} {
+	var fields []string
+	value := reflect.ValueOf(*request)
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Field(i).IsNil() {
+			fields = append(fields, strings.Split(value.Type().Field(i).Tag.Get("json"), ",")[0])
+		}
+	}
+	return map[string]interface{}{"fields": fields}
+}
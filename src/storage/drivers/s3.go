@@ -0,0 +1,227 @@
+package drivers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3DateFormat      = "20060102T150405Z"
+)
+
+// S3 stores files in an S3 compatible bucket, such as AWS S3 or MinIO.
+// Requests use path-style addressing and are signed with AWS signature version 4.
+type S3 struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Client    *http.Client
+}
+
+// Put uploads the body to the bucket.
+func (s *S3) Put(ctx context.Context, path string, body []byte, contentType string) error {
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	return s.do(ctx, http.MethodPut, path, body, headers)
+}
+
+// Delete removes the object from the bucket.
+func (s *S3) Delete(ctx context.Context, path string) error {
+	return s.do(ctx, http.MethodDelete, path, nil, http.Header{})
+}
+
+// URL returns a presigned GET url which expires after expiresIn.
+func (s *S3) URL(path string, expiresIn time.Duration) (string, error) {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	canonicalURI := s.canonicalURI(path)
+	query := s.presign(endpoint.Host, canonicalURI, expiresIn, time.Now().UTC())
+
+	return fmt.Sprintf("%s://%s%s?%s", endpoint.Scheme, endpoint.Host, canonicalURI, query), nil
+}
+
+// presign returns the signed query string of a GET request of the canonical uri.
+func (s *S3) presign(host, canonicalURI string, expiresIn time.Duration, now time.Time) string {
+	amzDate := now.Format(s3DateFormat)
+	scope := s.scope(now)
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     s3Algorithm,
+		"X-Amz-Credential":    s.AccessKey + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(expiresIn.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		canonicalURI,
+		canonicalQuery(query),
+		"host:" + host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	query["X-Amz-Signature"] = s.signature(now, amzDate, scope, canonicalRequest)
+	return canonicalQuery(query)
+}
+
+// do sends a signed request for the object and treats every non 2xx status as an error.
+func (s *S3) do(ctx context.Context, method, path string, body []byte, headers http.Header) error {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	canonicalURI := s.canonicalURI(path)
+	headers.Set("Host", endpoint.Host)
+	s.sign(headers, method, canonicalURI, sha256Hex(body), time.Now().UTC())
+
+	request, err := http.NewRequestWithContext(ctx, method, endpoint.Scheme+"://"+endpoint.Host+canonicalURI, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header = headers
+	request.Host = endpoint.Host
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("s3 %s %s failed with status %d: %s", method, path, response.StatusCode, message)
+	}
+	return nil
+}
+
+// sign sets the date, payload hash and authorization headers of a request, every header sent is signed.
+func (s *S3) sign(headers http.Header, method, canonicalURI, payloadHash string, now time.Time) {
+	amzDate := now.Format(s3DateFormat)
+	scope := s.scope(now)
+
+	headers.Set("X-Amz-Date", amzDate)
+	headers.Set("X-Amz-Content-Sha256", payloadHash)
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI,
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	signature := s.signature(now, amzDate, scope, canonicalRequest)
+	headers.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.AccessKey, scope, signedHeaders, signature))
+}
+
+// scope returns the credential scope of the day.
+func (s *S3) scope(now time.Time) string {
+	return fmt.Sprintf("%s/%s/%s/aws4_request", now.Format("20060102"), s.Region, s3Service)
+}
+
+// canonicalURI returns the encoded path-style uri of the object.
+func (s *S3) canonicalURI(path string) string {
+	segments := strings.Split(s.Bucket+"/"+strings.TrimLeft(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// signature derives the signing key of the day and signs the canonical request.
+func (s *S3) signature(now time.Time, amzDate, scope, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalQuery encodes the query sorted by key as required by signature version 4.
+func canonicalQuery(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, uriEncode(key)+"="+uriEncode(query[key]))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters.
+func uriEncode(value string) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' {
+			encoded.WriteByte(b)
+			continue
+		}
+		encoded.WriteString(fmt.Sprintf("%%%02X", b))
+	}
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
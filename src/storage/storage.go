@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"go-auth-otp-service/src/config"
+	"go-auth-otp-service/src/storage/drivers"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	connectOnce     sync.Once // Ensures the storage driver is initialised only once.
+	getInstanceOnce sync.Once // Ensures a single instance of Storage is created.
+	instance        *Storage  // Holds the singleton instance of Storage.
+)
+
+var (
+	// ErrNotServable is returned when the configured driver serves its files by itself, like S3.
+	ErrNotServable = errors.New("storage driver does not serve files")
+	// ErrMissingSigningKey is returned when the local driver has no key to sign its urls with.
+	ErrMissingSigningKey = errors.New("STORAGE_SIGNING_KEY is required by the local storage driver")
+)
+
+// IStorageDriver defines the interface for storage drivers.
+// It specifies the methods required for a storage driver to be compatible with the Storage struct.
+type IStorageDriver interface {
+	Put(ctx context.Context, path string, body []byte, contentType string) error // Put stores the body under the path.
+	Delete(ctx context.Context, path string) error                               // Delete removes the file under the path.
+	URL(path string, expiresIn time.Duration) (string, error)                    // URL returns an expiring url of the file.
+}
+
+// IServableDriver is implemented by drivers whose files are served by the application itself.
+type IServableDriver interface {
+	Open(path, expires, signature string) (*os.File, error) // Open verifies the signed url parameters and opens the file.
+}
+
+// Storage encapsulates the file operations and driver.
+// It serves as a central point for file interactions, leveraging a driver that implements the IStorageDriver interface.
+type Storage struct {
+	driver IStorageDriver // The storage driver, implementing IStorageDriver for file operations.
+}
+
+// Init initializes the storage by setting up the configured driver.
+func Init() (err error) {
+	return GetInstance().Connect()
+}
+
+// Connect sets up the driver selected by STORAGE_DRIVER, local disk is used by default.
+func (storage *Storage) Connect() (err error) {
+	connectOnce.Do(func() {
+		configs := config.GetInstance() // Retrieve configurations
+
+		switch configs.Get("STORAGE_DRIVER") {
+		case "s3":
+			storage.driver = &drivers.S3{
+				Endpoint:  configs.Get("S3_ENDPOINT"),
+				Region:    configs.Get("S3_REGION"),
+				Bucket:    configs.Get("S3_BUCKET"),
+				AccessKey: configs.Get("S3_ACCESS_KEY"),
+				SecretKey: configs.Get("S3_SECRET_KEY"),
+			}
+		default:
+			// without a key every signed url could be forged
+			if configs.Get("STORAGE_SIGNING_KEY") == "" {
+				err = ErrMissingSigningKey
+				return
+			}
+			storage.driver = &drivers.Local{
+				Root:       configs.Get("STORAGE_LOCAL_ROOT"),
+				BaseURL:    configs.Get("STORAGE_URL"),
+				SigningKey: configs.Get("STORAGE_SIGNING_KEY"),
+			}
+		}
+	})
+
+	return
+}
+
+// Put stores the body under the path.
+func (storage *Storage) Put(ctx context.Context, path string, body []byte, contentType string) error {
+	return storage.driver.Put(ctx, path, body, contentType)
+}
+
+// Delete removes the file under the path.
+func (storage *Storage) Delete(ctx context.Context, path string) error {
+	return storage.driver.Delete(ctx, path)
+}
+
+// URL returns a url of the file which stops working after expiresIn.
+func (storage *Storage) URL(path string, expiresIn time.Duration) (string, error) {
+	return storage.driver.URL(path, expiresIn)
+}
+
+// Open verifies a signed url and opens the file when the driver is served by the application.
+func (storage *Storage) Open(path, expires, signature string) (*os.File, error) {
+	driver, ok := storage.driver.(IServableDriver)
+	if !ok {
+		return nil, ErrNotServable
+	}
+	return driver.Open(path, expires, signature)
+}
+
+// GetInstance returns the singleton instance of the Storage.
+func GetInstance() *Storage {
+	getInstanceOnce.Do(func() {
+		instance = &Storage{} // Initialize the singleton instance
+	})
+	return instance
+}